@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+const CheckAuthor = "author"
+
+// reviewAuthor checks if the author of the PR is allowed to get an approval from bump-reviewer
+func (r *Reviewer) reviewAuthor(pr *github.PullRequest) error {
+	conf := configOrDefault(r.Config).Authors
+
+	if conf.RejectForks && isFork(pr) {
+		return &reviewError{Check: CheckAuthor, Message: fmt.Sprintf("Pull Request #%d comes from a fork. bump-reviewer only approves Pull Requests from branches of %s/%s.", pr.GetNumber(), r.Owner, r.Repo)}
+	}
+
+	if len(conf.Users) == 0 && len(conf.Teams) == 0 {
+		return nil
+	}
+
+	author := pr.GetUser().GetLogin()
+	for _, u := range conf.Users {
+		if strings.EqualFold(u, author) {
+			return nil
+		}
+	}
+
+	for _, team := range conf.Teams {
+		member, err := r.isTeamMember(team, author)
+		if err != nil {
+			return err
+		}
+
+		if member {
+			return nil
+		}
+	}
+
+	return &reviewError{Check: CheckAuthor, Message: fmt.Sprintf("@%s is not allowed to get an approval from bump-reviewer. Please ask one of the maintainers to review Pull Request #%d.", author, pr.GetNumber())}
+}
+
+// isTeamMember checks if a given user belongs to a team specified as "org/team-slug"
+func (r *Reviewer) isTeamMember(team, user string) (bool, error) {
+	org, slug := splitTeam(team)
+
+	t, err := r.GetTeamBySlug(org, slug)
+	if err != nil {
+		return false, err
+	}
+
+	if t == nil {
+		return false, fmt.Errorf("team %s is not found", team)
+	}
+
+	return r.IsTeamMember(t.GetID(), user)
+}
+
+func splitTeam(team string) (org, slug string) {
+	if i := strings.Index(team, "/"); i >= 0 {
+		return team[:i], team[i+1:]
+	}
+
+	return "", team
+}
+
+// isFork checks if the PR comes from another repository than its base
+func isFork(pr *github.PullRequest) bool {
+	head := pr.GetHead().GetRepo()
+	if head == nil {
+		// The head repository has been deleted
+		return true
+	}
+
+	return head.GetFullName() != pr.GetBase().GetRepo().GetFullName()
+}
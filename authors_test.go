@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func testPullRequestByAuthor(author, headRepo string) *github.PullRequest {
+	pr := &github.PullRequest{
+		Number: github.Int(1),
+		User:   &github.User{Login: github.String(author)},
+		Base:   &github.PullRequestBranch{Repo: &github.Repository{FullName: github.String("shuheiktgw/bump-reviewer")}},
+		Head:   &github.PullRequestBranch{},
+	}
+	if headRepo != "" {
+		pr.Head.Repo = &github.Repository{FullName: github.String(headRepo)}
+	}
+
+	return pr
+}
+
+func TestReviewer_ReviewAuthor(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+
+	mux.HandleFunc("/orgs/shuheiktgw-org/teams/releasers", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":2,"slug":"releasers"}`)
+	})
+	mux.HandleFunc("/teams/2/members/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/teams/2/members/releaser" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	cases := []struct {
+		authors  AuthorsConfig
+		pr       *github.PullRequest
+		rejected bool
+	}{
+		{
+			authors:  AuthorsConfig{Users: []string{"shuheiktgw"}},
+			pr:       testPullRequestByAuthor("ShuheiKtgw", "shuheiktgw/bump-reviewer"),
+			rejected: false,
+		},
+		{
+			authors:  AuthorsConfig{Users: []string{"shuheiktgw"}},
+			pr:       testPullRequestByAuthor("outsider", "shuheiktgw/bump-reviewer"),
+			rejected: true,
+		},
+		{
+			authors:  AuthorsConfig{Teams: []string{"shuheiktgw-org/releasers"}},
+			pr:       testPullRequestByAuthor("releaser", "shuheiktgw/bump-reviewer"),
+			rejected: false,
+		},
+		{
+			authors:  AuthorsConfig{Teams: []string{"shuheiktgw-org/releasers"}},
+			pr:       testPullRequestByAuthor("outsider", "shuheiktgw/bump-reviewer"),
+			rejected: true,
+		},
+		{
+			authors:  AuthorsConfig{RejectForks: true},
+			pr:       testPullRequestByAuthor("shuheiktgw", "outsider/bump-reviewer"),
+			rejected: true,
+		},
+		{
+			authors:  AuthorsConfig{RejectForks: true},
+			pr:       testPullRequestByAuthor("shuheiktgw", ""),
+			rejected: true,
+		},
+		{
+			authors:  AuthorsConfig{RejectForks: true},
+			pr:       testPullRequestByAuthor("shuheiktgw", "shuheiktgw/bump-reviewer"),
+			rejected: false,
+		},
+	}
+
+	for i, tc := range cases {
+		reviewer.Config = DefaultConfig()
+		reviewer.Config.Authors = tc.authors
+
+		err := reviewer.reviewAuthor(tc.pr)
+		if _, ok := err.(review); ok != tc.rejected {
+			t.Errorf("#%d Reviewer.reviewAuthor returned unexpected error: %v", i, err)
+		}
+	}
+}
+
+func TestReviewer_ReviewAuthor_TeamNotFound(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+
+	mux.HandleFunc("/orgs/shuheiktgw-org/teams/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+	})
+
+	reviewer.Config = DefaultConfig()
+	reviewer.Config.Authors = AuthorsConfig{Teams: []string{"shuheiktgw-org/releasers"}}
+
+	err := reviewer.reviewAuthor(testPullRequestByAuthor("releaser", "shuheiktgw/bump-reviewer"))
+	if _, ok := err.(review); ok || err == nil {
+		t.Errorf("Reviewer.reviewAuthor returned unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/blang/semver"
+)
+
+// Kinds of version bumps
+const (
+	BumpPatch = "patch"
+	BumpMinor = "minor"
+	BumpMajor = "major"
+)
+
+var bumpKindOrder = map[string]int{BumpPatch: 1, BumpMinor: 2, BumpMajor: 3}
+
+func validBumpKind(kind string) bool {
+	_, ok := bumpKindOrder[kind]
+	return ok
+}
+
+// nextVersion bumps a given version by a given kind
+func nextVersion(version, kind string) (string, error) {
+	v, err := semver.New(version)
+	if err != nil {
+		return "", err
+	}
+	v.Pre = nil
+	v.Build = nil
+
+	switch kind {
+	case BumpPatch:
+		v.Patch++
+	case BumpMinor:
+		v.Minor++
+		v.Patch = 0
+	case BumpMajor:
+		v.Major++
+		v.Minor = 0
+		v.Patch = 0
+	default:
+		return "", fmt.Errorf("unknown bump kind: %s", kind)
+	}
+
+	return v.String(), nil
+}
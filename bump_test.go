@@ -0,0 +1,57 @@
+package main
+
+import "testing"
+
+func TestNextVersion(t *testing.T) {
+	cases := []struct {
+		version  string
+		kind     string
+		expected string
+	}{
+		{version: "1.2.3", kind: BumpPatch, expected: "1.2.4"},
+		{version: "1.2.3", kind: BumpMinor, expected: "1.3.0"},
+		{version: "1.2.3", kind: BumpMajor, expected: "2.0.0"},
+		{version: "1.2.3-beta.1", kind: BumpPatch, expected: "1.2.4"},
+	}
+
+	for i, tc := range cases {
+		got, err := nextVersion(tc.version, tc.kind)
+		if err != nil {
+			t.Fatalf("#%d nextVersion returned unexpected error: %s", i, err)
+		}
+
+		if got != tc.expected {
+			t.Errorf("#%d nextVersion returned %s, want %s", i, got, tc.expected)
+		}
+	}
+}
+
+func TestNextVersion_Invalid(t *testing.T) {
+	if _, err := nextVersion("1.2", BumpPatch); err == nil {
+		t.Errorf("nextVersion did not return an error for an invalid version")
+	}
+
+	if _, err := nextVersion("1.2.3", "huge"); err == nil {
+		t.Errorf("nextVersion did not return an error for an unknown kind")
+	}
+}
+
+func TestBumpKindOf(t *testing.T) {
+	cases := []struct {
+		from     string
+		to       string
+		expected string
+	}{
+		{from: "1.2.3", to: "1.2.4", expected: BumpPatch},
+		{from: "1.2.3", to: "1.3.0", expected: BumpMinor},
+		{from: "1.2.3", to: "2.0.0", expected: BumpMajor},
+		{from: "1.2.3", to: "1.2.5", expected: ""},
+		{from: "1.2.3", to: "1.3", expected: ""},
+	}
+
+	for i, tc := range cases {
+		if got := bumpKindOf(semverScheme{}, tc.from, tc.to); got != tc.expected {
+			t.Errorf("#%d bumpKindOf returned %q, want %q", i, got, tc.expected)
+		}
+	}
+}
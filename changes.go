@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/google/go-github/github"
+)
+
+const CheckChanges = "changes"
+
+// reviewChanges checks if the base branch of the PR has something to release since the latest release.
+// It returns the comparison between the latest release and the base of the PR.
+func (r *Reviewer) reviewChanges(pr *github.PullRequest, pkg *Package, tag string) (*github.CommitsComparison, error) {
+	base := pr.GetBase()
+
+	cc, err := r.CompareCommits(tag, base.GetSHA())
+	if err != nil {
+		return nil, err
+	}
+
+	// The latest release has to be an ancestor of the base branch
+	switch cc.GetStatus() {
+	case "ahead", "identical":
+	default:
+		return nil, &reviewError{Check: CheckChanges, Message: fmt.Sprintf("The latest release %s is not an ancestor of %s. It might have been released from another branch.", tag, base.GetRef())}
+	}
+
+	if cc.GetAheadBy() == 0 {
+		return nil, &reviewError{Check: CheckChanges, Message: fmt.Sprintf("%s has no commits since the latest release %s, so there is nothing to release.", base.GetRef(), tag)}
+	}
+
+	// Earlier bump up commits change nothing but version.rb, and changes of other packages are not of the package
+	for _, f := range cc.Files {
+		if pkg.owns(f.GetFilename()) && configOrDefault(r.Config).packageAt(r.Repo, f.GetFilename()) == nil {
+			return cc, nil
+		}
+	}
+
+	if pkg.Dir != "" {
+		return nil, &reviewError{Check: CheckChanges, Message: fmt.Sprintf("%s has no changes in %s other than the version files since the latest release %s, so there is nothing to release.", base.GetRef(), pkg.Dir, tag)}
+	}
+
+	return nil, &reviewError{Check: CheckChanges, Message: fmt.Sprintf("%s has no changes other than %s since the latest release %s, so there is nothing to release.", base.GetRef(), pkg.fileName(), tag)}
+}
+
+// packageCommits returns the commits of a comparison up to head which change files of a package
+func packageCommits(client *GitHubClient, pkg *Package, cc *github.CommitsComparison, head string) ([]github.RepositoryCommit, error) {
+	if pkg.Dir == "" {
+		return cc.Commits, nil
+	}
+
+	listed, err := client.ListCommits(&github.CommitsListOptions{
+		SHA:         head,
+		Path:        pkg.Dir,
+		Since:       cc.GetMergeBaseCommit().GetCommit().GetCommitter().GetDate(),
+		ListOptions: github.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changed := map[string]bool{}
+	for _, c := range listed {
+		changed[c.GetSHA()] = true
+	}
+
+	var commits []github.RepositoryCommit
+	for _, c := range cc.Commits {
+		if changed[c.GetSHA()] {
+			commits = append(commits, c)
+		}
+	}
+
+	return commits, nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestReviewer_ReviewChanges(t *testing.T) {
+	cases := []struct {
+		comparison string
+		rejected   bool
+	}{
+		{comparison: `{"status":"ahead","ahead_by":2,"files":[{"filename":"lib/bump-reviewer/version.rb"},{"filename":"lib/bump-reviewer/cli.rb"}]}`},
+		{comparison: `{"status":"identical","ahead_by":0,"files":[]}`, rejected: true},
+		{comparison: `{"status":"ahead","ahead_by":1,"files":[{"filename":"lib/bump-reviewer/version.rb"}]}`, rejected: true},
+		{comparison: `{"status":"diverged","ahead_by":3,"behind_by":1,"files":[{"filename":"lib/bump-reviewer/cli.rb"}]}`, rejected: true},
+		{comparison: `{"status":"behind","ahead_by":0,"behind_by":1,"files":[]}`, rejected: true},
+	}
+
+	pr := &github.PullRequest{Number: github.Int(1), Base: &github.PullRequestBranch{Ref: github.String("master"), SHA: github.String("base123")}}
+
+	for i, tc := range cases {
+		reviewer, mux, _, tearDown := setupReviewer()
+		setCompareHandler(mux, "v1.0.1", "base123", tc.comparison)
+
+		_, err := reviewer.reviewChanges(pr, testPackage(reviewer), "v1.0.1")
+		if tc.rejected {
+			if re, ok := err.(*reviewError); !ok || re.Check != CheckChanges {
+				t.Errorf("#%d Reviewer.reviewChanges returned %v, want reviewError of %s check", i, err, CheckChanges)
+			}
+		} else if err != nil {
+			t.Errorf("#%d Reviewer.reviewChanges returned unexpected error: %s", i, err)
+		}
+
+		tearDown()
+	}
+}
+
+func TestReviewer_ReviewChanges_Packages(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+	reviewer.Config = testPackagesConfig(t, "")
+
+	// Only gem-a has changed since the releases, gem-b has only been bumped
+	setCompareHandler(mux, "v1.0.1", "base123", `{"status":"ahead","ahead_by":2,"files":[{"filename":"gems/gem-a/lib/gem-a.rb"},{"filename":"gems/b/lib/gem_b/version.rb"}]}`)
+
+	pr := &github.PullRequest{Number: github.Int(1), Base: &github.PullRequestBranch{Ref: github.String("master"), SHA: github.String("base123")}}
+	pkgs := reviewer.Config.packages(reviewer.Repo)
+
+	if _, err := reviewer.reviewChanges(pr, pkgs[0], "v1.0.1"); err != nil {
+		t.Errorf("Reviewer.reviewChanges returned unexpected error for %s: %s", pkgs[0].Name, err)
+	}
+
+	_, err := reviewer.reviewChanges(pr, pkgs[1], "v1.0.1")
+	if re, ok := err.(*reviewError); !ok || re.Check != CheckChanges {
+		t.Errorf("Reviewer.reviewChanges returned %v for %s, want reviewError of %s check", err, pkgs[1].Name, CheckChanges)
+	}
+}
+
+func TestPackageCommits(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+	reviewer.Config = testPackagesConfig(t, "")
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/commits", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{"sha": "base123", "path": "gems/gem-a", "since": "2019-05-01T00:00:00Z", "per_page": "100"})
+		fmt.Fprint(w, `[{"sha":"a3"},{"sha":"a1"},{"sha":"a0"}]`)
+	})
+
+	var cc github.CommitsComparison
+	comparison := `{"merge_base_commit":{"commit":{"committer":{"date":"2019-05-01T00:00:00Z"}}},"commits":[{"sha":"a1"},{"sha":"b2"},{"sha":"a3"}]}`
+	if err := json.Unmarshal([]byte(comparison), &cc); err != nil {
+		t.Fatalf("json.Unmarshal returned unexpected error: %s", err)
+	}
+
+	cases := []struct {
+		pkg  *Package
+		want []string
+	}{
+		{pkg: reviewer.Config.packages(reviewer.Repo)[0], want: []string{"a1", "a3"}},
+		{pkg: DefaultConfig().packages(reviewer.Repo)[0], want: []string{"a1", "b2", "a3"}},
+	}
+
+	for i, tc := range cases {
+		commits, err := packageCommits(reviewer.GitHubClient, tc.pkg, &cc, "base123")
+		if err != nil {
+			t.Fatalf("#%d packageCommits returned unexpected error: %s", i, err)
+		}
+
+		var got []string
+		for _, c := range commits {
+			got = append(got, c.GetSHA())
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("#%d packageCommits returned %v, want %v", i, got, tc.want)
+		}
+	}
+}
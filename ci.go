@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+const CheckCI = "CI"
+
+// States of CI contexts
+const (
+	CIStateSuccess = "success"
+	CIStatePending = "pending"
+	CIStateFailure = "failure"
+)
+
+// maxCIInterval caps the backoff between polls of CI states
+const maxCIInterval = time.Minute
+
+// ContextResult is the state of a required CI context
+type ContextResult struct {
+	Name  string
+	State string
+}
+
+// reviewCI waits until all the required CI contexts of the head commit succeed.
+// It fails if any of them fails, or if they do not finish in time.
+func (r *Reviewer) reviewCI(result *ReviewResult) error {
+	conf := configOrDefault(r.Config).CI
+
+	deadline := r.now().Add(conf.timeout)
+	interval := conf.interval
+	for {
+		states, err := r.ciStates(result.HeadSHA)
+		if err != nil {
+			return err
+		}
+
+		result.CI = nil
+		var pending, failed []string
+		for _, name := range conf.RequiredContexts {
+			state, ok := states[name]
+			if !ok {
+				state = CIStatePending
+			}
+			result.CI = append(result.CI, ContextResult{Name: name, State: state})
+
+			switch state {
+			case CIStateFailure:
+				failed = append(failed, name)
+			case CIStatePending:
+				pending = append(pending, name)
+			}
+		}
+
+		if len(failed) != 0 {
+			return &reviewError{Check: CheckCI, Message: fmt.Sprintf("The required CI checks failed on %s: %s. bump-reviewer approves the Pull Request only after all the required checks succeed.", result.HeadSHA, strings.Join(failed, ", "))}
+		}
+
+		if len(pending) == 0 {
+			return nil
+		}
+
+		if !r.now().Add(interval).Before(deadline) {
+			return &reviewError{Check: CheckCI, Message: fmt.Sprintf("The required CI checks did not finish on %s within %s: %s. bump-reviewer approves the Pull Request only after all the required checks succeed.", result.HeadSHA, conf.timeout, strings.Join(pending, ", "))}
+		}
+
+		r.sleep(interval)
+		if interval *= 2; interval > maxCIInterval {
+			interval = maxCIInterval
+		}
+	}
+}
+
+// ciStates collects the states of commit statuses and check runs of a given ref
+func (r *Reviewer) ciStates(ref string) (map[string]string, error) {
+	states := map[string]string{}
+
+	cs, err := r.GetCombinedStatus(ref, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range cs.Statuses {
+		switch s.GetState() {
+		case "success":
+			states[s.GetContext()] = CIStateSuccess
+		case "pending":
+			states[s.GetContext()] = CIStatePending
+		default:
+			states[s.GetContext()] = CIStateFailure
+		}
+	}
+
+	cr, err := r.ListCheckRunsForRef(ref, &github.ListCheckRunsOptions{ListOptions: github.ListOptions{PerPage: 100}})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, run := range cr.CheckRuns {
+		if run.GetStatus() != "completed" {
+			states[run.GetName()] = CIStatePending
+			continue
+		}
+
+		switch run.GetConclusion() {
+		case "success", "neutral", "skipped":
+			states[run.GetName()] = CIStateSuccess
+		default:
+			states[run.GetName()] = CIStateFailure
+		}
+	}
+
+	return states, nil
+}
+
+func (r *Reviewer) now() time.Time {
+	if r.clock != nil {
+		return r.clock()
+	}
+
+	return time.Now()
+}
+
+func (r *Reviewer) sleep(d time.Duration) {
+	if r.sleeper != nil {
+		r.sleeper(d)
+		return
+	}
+
+	time.Sleep(d)
+}
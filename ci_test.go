@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setCIHandlers serves the given responses of commit statuses and check runs in turn
+func setCIHandlers(mux *http.ServeMux, sha string, statuses, checkRuns []string) {
+	var s, c int
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/commits/%v/status", testGitHubOwner, testGitHubRepo, sha), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, statuses[s])
+		if s < len(statuses)-1 {
+			s++
+		}
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/commits/%v/check-runs", testGitHubOwner, testGitHubRepo, sha), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, checkRuns[c])
+		if c < len(checkRuns)-1 {
+			c++
+		}
+	})
+}
+
+func setupCIReviewer(contexts ...string) (*Reviewer, *http.ServeMux, *[]time.Duration, func()) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	reviewer.Config = DefaultConfig()
+	reviewer.Config.CI.RequiredContexts = contexts
+
+	now := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+	var slept []time.Duration
+	reviewer.clock = func() time.Time { return now }
+	reviewer.sleeper = func(d time.Duration) {
+		slept = append(slept, d)
+		now = now.Add(d)
+	}
+
+	return reviewer, mux, &slept, tearDown
+}
+
+func TestReviewer_ReviewCI_Success(t *testing.T) {
+	reviewer, mux, slept, tearDown := setupCIReviewer("ci/circleci: build", "rspec")
+	defer tearDown()
+
+	setCIHandlers(mux, "abc123",
+		[]string{
+			`{"statuses":[{"context":"ci/circleci: build","state":"pending"}]}`,
+			`{"statuses":[{"context":"ci/circleci: build","state":"success"}]}`,
+		},
+		[]string{
+			`{"check_runs":[]}`,
+			`{"check_runs":[{"name":"rspec","status":"in_progress"}]}`,
+			`{"check_runs":[{"name":"rspec","status":"completed","conclusion":"success"}]}`,
+		},
+	)
+
+	result := &ReviewResult{HeadSHA: "abc123"}
+	if err := reviewer.reviewCI(result); err != nil {
+		t.Fatalf("Reviewer.reviewCI returned unexpected error: %s", err)
+	}
+
+	if want := []time.Duration{10 * time.Second, 20 * time.Second}; fmt.Sprint(*slept) != fmt.Sprint(want) {
+		t.Errorf("Reviewer.reviewCI slept %v, want %v", *slept, want)
+	}
+
+	if got, want := fmt.Sprint(result.CI), "[{ci/circleci: build success} {rspec success}]"; got != want {
+		t.Errorf("Reviewer.reviewCI returned unexpected result: want: %s, got: %s", want, got)
+	}
+}
+
+func TestReviewer_ReviewCI_Failure(t *testing.T) {
+	reviewer, mux, _, tearDown := setupCIReviewer("ci/circleci: build", "rspec")
+	defer tearDown()
+
+	setCIHandlers(mux, "abc123",
+		[]string{`{"statuses":[{"context":"ci/circleci: build","state":"error"}]}`},
+		[]string{`{"check_runs":[{"name":"rspec","status":"in_progress"}]}`},
+	)
+
+	err := reviewer.reviewCI(&ReviewResult{HeadSHA: "abc123"})
+	re, ok := err.(*reviewError)
+	if !ok || re.Check != CheckCI || !strings.Contains(re.Message, "failed on abc123: ci/circleci: build.") {
+		t.Fatalf("Reviewer.reviewCI returned unexpected error: %v", err)
+	}
+}
+
+func TestReviewer_ReviewCI_Timeout(t *testing.T) {
+	reviewer, mux, slept, tearDown := setupCIReviewer("rspec")
+	defer tearDown()
+	reviewer.Config.CI.timeout = 3 * time.Minute
+
+	setCIHandlers(mux, "abc123",
+		[]string{`{"statuses":[]}`},
+		[]string{`{"check_runs":[{"name":"rspec","status":"queued"}]}`},
+	)
+
+	err := reviewer.reviewCI(&ReviewResult{HeadSHA: "abc123"})
+	re, ok := err.(*reviewError)
+	if !ok || re.Check != CheckCI || !strings.Contains(re.Message, "did not finish on abc123 within 3m0s: rspec.") {
+		t.Fatalf("Reviewer.reviewCI returned unexpected error: %v", err)
+	}
+
+	if want := []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute}; fmt.Sprint(*slept) != fmt.Sprint(want) {
+		t.Errorf("Reviewer.reviewCI slept %v, want %v", *slept, want)
+	}
+}
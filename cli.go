@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"strings"
 )
 
 const (
@@ -12,18 +13,125 @@ const (
 	ExitCodeReviewFailed
 	ExitCodeParseFlagsError
 	ExitCodeInvalidFlagError
+	ExitCodeInvalidConfigError
+	ExitCodeSkipped
+	ExitCodeInvalidTokenError
+	ExitCodeSkippedDraft
+	ExitCodeSkippedClosed
+	ExitCodeSkippedMerged
+	ExitCodeSkippedBaseBranch
+	ExitCodeCIFailed
+	ExitCodeReleaseFailed
 )
 
+// skipExitCodes are the exit codes of the reasons to skip reviews
+var skipExitCodes = map[string]int{
+	SkipLabel:      ExitCodeSkipped,
+	SkipDraft:      ExitCodeSkippedDraft,
+	SkipClosed:     ExitCodeSkippedClosed,
+	SkipMerged:     ExitCodeSkippedMerged,
+	SkipBaseBranch: ExitCodeSkippedBaseBranch,
+}
+
 type CLI struct {
 	outStream, errStream io.Writer
 }
 
+// stringsFlag is a flag which can be specified multiple times
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringsFlag) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+// options are the options shared by every command
+type options struct {
+	owner  string
+	repo   string
+	token  string
+	number int
+	config string
+}
+
+func (o *options) register(flags *flag.FlagSet) {
+	flags.StringVar(&o.owner, "owner", "", "")
+	flags.StringVar(&o.owner, "o", "", "")
+
+	flags.StringVar(&o.repo, "repo", "", "")
+	flags.StringVar(&o.repo, "r", "", "")
+
+	flags.StringVar(&o.token, "token", "", "")
+	flags.StringVar(&o.token, "t", "", "")
+
+	flags.IntVar(&o.number, "number", 0, "")
+	flags.IntVar(&o.number, "n", 0, "")
+
+	flags.StringVar(&o.config, "config", "", "")
+	flags.StringVar(&o.config, "c", "", "")
+}
+
+// setup validates the options and loads the config
+func (cli *CLI) setup(o *options, requireNumber bool) (*GitHubClient, *Config, int) {
+	if len(o.owner) == 0 {
+		fmt.Fprintf(cli.errStream, "Failed to set up bump-reviewer: GitHub owner is missing\n"+
+			"Please set it via `-o` option\n\n")
+		return nil, nil, ExitCodeInvalidFlagError
+	}
+
+	if len(o.repo) == 0 {
+		fmt.Fprintf(cli.errStream, "Failed to set up bump-reviewer: GitHub repository is missing\n"+
+			"Please set it via `-r` option\n\n")
+		return nil, nil, ExitCodeInvalidFlagError
+	}
+
+	if len(o.token) == 0 {
+		fmt.Fprintf(cli.errStream, "Failed to set up bump-reviewer: GitHub Personal Access Token is missing\n"+
+			"Please set it via `-t` option\n\n")
+		return nil, nil, ExitCodeInvalidFlagError
+	}
+
+	if requireNumber && o.number == 0 {
+		fmt.Fprintf(cli.errStream, "Failed to set up bump-reviewer: Pull Request number is missing\n"+
+			"Please set it via `-n` option\n\n")
+		return nil, nil, ExitCodeInvalidFlagError
+	}
+
+	conf := DefaultConfig()
+	if len(o.config) != 0 {
+		c, err := LoadConfig(o.config)
+		if err != nil {
+			fmt.Fprintf(cli.errStream, "Failed to set up bump-reviewer: %s\n\n", err)
+			return nil, nil, ExitCodeInvalidConfigError
+		}
+		conf = c
+	}
+
+	return NewGitHubClient(o.owner, o.repo, o.token), conf, ExitCodeOK
+}
+
 func (cli *CLI) Run(args []string) int {
+	if len(args) > 1 {
+		switch args[1] {
+		case "release":
+			return cli.runRelease(args[1:])
+		case "notes":
+			return cli.runNotes(args[1:])
+		case "propose":
+			return cli.runPropose(args[1:])
+		}
+	}
+
 	var (
-		owner   string
-		repo    string
-		token   string
-		number  int
+		opts    options
+		sticky  bool
+		merge   bool
+		labels  stringsFlag
+		all     bool
 		version bool
 	)
 
@@ -32,17 +140,14 @@ func (cli *CLI) Run(args []string) int {
 		fmt.Fprint(cli.outStream, usage)
 	}
 
-	flags.StringVar(&owner, "owner", "", "")
-	flags.StringVar(&owner, "o", "", "")
+	opts.register(flags)
 
-	flags.StringVar(&repo, "repo", "", "")
-	flags.StringVar(&repo, "r", "", "")
+	flags.BoolVar(&sticky, "sticky", false, "")
 
-	flags.StringVar(&token, "token", "", "")
-	flags.StringVar(&token, "t", "", "")
+	flags.BoolVar(&merge, "merge", false, "")
 
-	flags.IntVar(&number, "number", 0, "")
-	flags.IntVar(&number, "n", 0, "")
+	flags.Var(&labels, "require-label", "")
+	flags.BoolVar(&all, "require-all-labels", false, "")
 
 	flags.BoolVar(&version, "version", false, "")
 	flags.BoolVar(&version, "v", false, "")
@@ -56,36 +161,28 @@ func (cli *CLI) Run(args []string) int {
 		return ExitCodeOK
 	}
 
-	if len(owner) == 0 {
-		fmt.Fprintf(cli.errStream, "Failed to set up bump-reviewer: GitHub owner is missing\n"+
-			"Please set it via `-o` option\n\n")
-		return ExitCodeInvalidFlagError
-	}
-
-	if len(repo) == 0 {
-		fmt.Fprintf(cli.errStream, "Failed to set up bump-reviewer: GitHub repository is missing\n"+
-			"Please set it via `-r` option\n\n")
-		return ExitCodeInvalidFlagError
-	}
-
-	if len(token) == 0 {
-		fmt.Fprintf(cli.errStream, "Failed to set up bump-reviewer: GitHub Personal Access Token is missing\n"+
-			"Please set it via `-t` option\n\n")
-		return ExitCodeInvalidFlagError
-	}
-
-	if number == 0 {
-		fmt.Fprintf(cli.errStream, "Failed to set up bump-reviewer: Pull Request number is missing\n"+
-			"Please set it via `-n` option\n\n")
-		return ExitCodeInvalidFlagError
+	client, conf, code := cli.setup(&opts, true)
+	if code != ExitCodeOK {
+		return code
 	}
+	number := opts.number
 
-	client := NewGitHubClient(owner, repo, token)
-	reviewer := Reviewer{client}
+	reviewer := Reviewer{GitHubClient: client, Config: conf, Sticky: sticky, Merge: merge, RequiredLabels: labels, RequireAllLabels: all}
 
 	if err := reviewer.Review(number); err != nil {
+		if t, ok := err.(*tokenError); ok {
+			fmt.Fprintf(cli.errStream, "Failed to set up bump-reviewer: %s\n\n", t)
+			return ExitCodeInvalidTokenError
+		}
+		if s, ok := err.(skip); ok {
+			fmt.Fprintf(cli.outStream, "bump-reviewer skipped Pull Request #%d: %s\n\n", number, s.skip())
+			return skipExitCodes[s.reason()]
+		}
 		if r, ok := err.(review); ok {
 			fmt.Fprintf(cli.errStream, "Pull Request #%d did not pass the review because of the following reason\n\n%s", number, r.review())
+			if re, ok := err.(*reviewError); ok && re.Check == CheckCI {
+				return ExitCodeCIFailed
+			}
 			return ExitCodeReviewFailed
 		}
 		fmt.Fprintf(cli.errStream, `bump-reviewer failed to review because of the following error.
@@ -98,20 +195,227 @@ You might encounter a bug with bump-reviewer, and if so, please report it to htt
 		return ExitCodeError
 	}
 
+	if merge && conf.Merge.AutoMerge {
+		fmt.Fprintf(cli.outStream, "bump-reviewer successfully approved your Pull Request and enabled auto-merge.\n\n")
+		return ExitCodeOK
+	}
+
+	if merge {
+		fmt.Fprintf(cli.outStream, "bump-reviewer successfully approved and merged your Pull Request.\n\n")
+		return ExitCodeOK
+	}
+
 	fmt.Fprintf(cli.outStream, "bump-reviewer successfully approved your Pull Request.\n\n")
 	return ExitCodeOK
 }
 
-var usage = `Usage: bump-reviewer [options...]
+var usage = `Usage: bump-reviewer [command] [options...]
 
 bump-reviewer is a command to review and approve bump up Pull Requests
 
+COMMANDS:
+  release                   creates the tag and the GitHub Release of a merged bump up Pull Request
+  notes                     prints the release notes of a bump up Pull Request
+  propose                   opens a bump up Pull Request
+
 OPTIONS:
   --owner value, -o value   specifies GitHub Owner
   --repo value, -r value    specifies GitHub Repository Name
   --token value, -v value   specifies GitHub Personal Access Token
   --number value, -n value  specifies GitHub Pull Request Number to review
+  --config value, -c value  specifies a path to the config file
+  --sticky                  reports failures in a single comment edited in place
+  --merge                   merges the Pull Request after approving it
+  --require-label value     reviews the Pull Request only if it has the label, can be specified multiple times
+  --require-all-labels      requires all the labels specified with --require-label instead of any of them
   --version, -v             prints the current version
   --help, -h                prints help
 
 `
+
+func (cli *CLI) runRelease(args []string) int {
+	var opts options
+
+	flags := flag.NewFlagSet(Name+" release", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(cli.outStream, releaseUsage)
+	}
+
+	opts.register(flags)
+
+	if err := flags.Parse(args[1:]); err != nil {
+		return ExitCodeParseFlagsError
+	}
+
+	client, conf, code := cli.setup(&opts, true)
+	if code != ExitCodeOK {
+		return code
+	}
+
+	releaser := Releaser{GitHubClient: client, Config: conf}
+
+	releases, err := releaser.Release(opts.number)
+	if err != nil {
+		if r, ok := err.(*releaseError); ok {
+			fmt.Fprintf(cli.errStream, "bump-reviewer did not release Pull Request #%d because of the following reason\n\n%s\n\n", opts.number, r)
+			return ExitCodeReleaseFailed
+		}
+		fmt.Fprintf(cli.errStream, `bump-reviewer failed to release because of the following error.
+
+%s
+
+You might encounter a bug with bump-reviewer, and if so, please report it to https://github.com/shuheiktgw/bump-reviewer/issues
+
+`, err)
+		return ExitCodeError
+	}
+
+	for _, release := range releases {
+		fmt.Fprintf(cli.outStream, "bump-reviewer successfully released %s: %s\n\n", release.GetTagName(), release.GetHTMLURL())
+	}
+	return ExitCodeOK
+}
+
+var releaseUsage = `Usage: bump-reviewer release [options...]
+
+release creates the tag and the GitHub Release of a merged bump up Pull Request.
+It is safe to run more than once.
+
+OPTIONS:
+  --owner value, -o value   specifies GitHub Owner
+  --repo value, -r value    specifies GitHub Repository Name
+  --token value, -v value   specifies GitHub Personal Access Token
+  --number value, -n value  specifies the merged GitHub Pull Request Number to release
+  --config value, -c value  specifies a path to the config file
+  --help, -h                prints help
+
+`
+
+func (cli *CLI) runNotes(args []string) int {
+	var opts options
+
+	flags := flag.NewFlagSet(Name+" notes", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(cli.outStream, notesUsage)
+	}
+
+	opts.register(flags)
+
+	if err := flags.Parse(args[1:]); err != nil {
+		return ExitCodeParseFlagsError
+	}
+
+	client, conf, code := cli.setup(&opts, true)
+	if code != ExitCodeOK {
+		return code
+	}
+
+	releaser := Releaser{GitHubClient: client, Config: conf}
+
+	notes, err := releaser.Notes(opts.number)
+	if err != nil {
+		fmt.Fprintf(cli.errStream, `bump-reviewer failed to generate the release notes because of the following error.
+
+%s
+
+You might encounter a bug with bump-reviewer, and if so, please report it to https://github.com/shuheiktgw/bump-reviewer/issues
+
+`, err)
+		return ExitCodeError
+	}
+
+	fmt.Fprint(cli.outStream, notes)
+	return ExitCodeOK
+}
+
+var notesUsage = `Usage: bump-reviewer notes [options...]
+
+notes prints the release notes of a bump up Pull Request, which list the Pull Requests
+merged since the latest release, without creating anything.
+
+OPTIONS:
+  --owner value, -o value   specifies GitHub Owner
+  --repo value, -r value    specifies GitHub Repository Name
+  --token value, -v value   specifies GitHub Personal Access Token
+  --number value, -n value  specifies the bump up GitHub Pull Request Number
+  --config value, -c value  specifies a path to the config file
+  --help, -h                prints help
+
+`
+
+func (cli *CLI) runPropose(args []string) int {
+	var (
+		opts     options
+		kind     string
+		labels   stringsFlag
+		pkg      string
+		reviewer string
+	)
+
+	flags := flag.NewFlagSet(Name+" propose", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(cli.outStream, proposeUsage)
+	}
+
+	opts.register(flags)
+
+	flags.StringVar(&kind, "kind", BumpPatch, "")
+	flags.StringVar(&kind, "k", BumpPatch, "")
+
+	flags.Var(&labels, "label", "")
+
+	flags.StringVar(&pkg, "package", "", "")
+
+	flags.StringVar(&reviewer, "reviewer", "", "")
+
+	if err := flags.Parse(args[1:]); err != nil {
+		return ExitCodeParseFlagsError
+	}
+
+	client, conf, code := cli.setup(&opts, false)
+	if code != ExitCodeOK {
+		return code
+	}
+
+	if !validBumpKind(kind) {
+		fmt.Fprintf(cli.errStream, "Failed to set up bump-reviewer: unknown bump kind %q\n"+
+			"Please set one of patch, minor or major via `--kind` option\n\n", kind)
+		return ExitCodeInvalidFlagError
+	}
+
+	proposer := Proposer{GitHubClient: client, Config: conf, Labels: labels, Package: pkg, Reviewer: reviewer}
+
+	pr, err := proposer.Propose(kind)
+	if err != nil {
+		fmt.Fprintf(cli.errStream, `bump-reviewer failed to open a bump up Pull Request because of the following error.
+
+%s
+
+You might encounter a bug with bump-reviewer, and if so, please report it to https://github.com/shuheiktgw/bump-reviewer/issues
+
+`, err)
+		return ExitCodeError
+	}
+
+	fmt.Fprintf(cli.outStream, "bump-reviewer opened Pull Request #%d: %s\n\n", pr.GetNumber(), pr.GetHTMLURL())
+	return ExitCodeOK
+}
+
+var proposeUsage = `Usage: bump-reviewer propose [options...]
+
+propose bumps the version of the latest release on a new branch and opens a Pull Request
+of it against the default branch.
+
+OPTIONS:
+  --owner value, -o value   specifies GitHub Owner
+  --repo value, -r value    specifies GitHub Repository Name
+  --token value, -v value   specifies GitHub Personal Access Token
+  --config value, -c value  specifies a path to the config file
+  --kind value, -k value    specifies the kind of the version bump, one of patch, minor or major (default: patch)
+  --label value             adds the label to the Pull Request, can be specified multiple times.
+                            Add the labels the review requires with --require-label, or it skips the Pull Request
+  --package value           specifies the package to bump, required when the config has packages
+  --reviewer value          specifies the user bump-reviewer reviews as, and fails if the token belongs to them
+  --help, -h                prints help
+
+`
@@ -41,10 +41,33 @@ func TestCLI_Run(t *testing.T) {
 		{
 			command:           "bump-reviewer -o shuheiktgw -r bump-reviewer -t 1234abcd -n 1",
 			expectedOutStream: "",
-			expectedErrStream: "bump-reviewer failed to review because of the following error.\n\n" +
-				"GET https://api.github.com/repos/shuheiktgw/bump-reviewer/pulls/1/files: 401 Bad credentials []\n\n" +
-				"You might encounter a bug with bump-reviewer, and if so, please report it to https://github.com/shuheiktgw/bump-reviewer/issues\n\n",
-			expectedExitCode: ExitCodeError,
+			expectedErrStream: "Failed to set up bump-reviewer: GitHub Personal Access Token is invalid or expired: " +
+				"GET https://api.github.com/user: 401 Bad credentials []\n\n",
+			expectedExitCode: ExitCodeInvalidTokenError,
+		},
+		{
+			command:           "bump-reviewer -o shuheiktgw -r bump-reviewer -t 1234abcd -n 1 -c testdata/invalid_config.json",
+			expectedOutStream: "",
+			expectedErrStream: "Failed to set up bump-reviewer: failed to parse testdata/invalid_config.json: json: unknown field \"template\"\n\n",
+			expectedExitCode:  ExitCodeInvalidConfigError,
+		},
+		{
+			command:           "bump-reviewer release -o shuheiktgw -r bump-reviewer -t 1234abcd",
+			expectedOutStream: "",
+			expectedErrStream: "Failed to set up bump-reviewer: Pull Request number is missing\nPlease set it via `-n` option\n\n",
+			expectedExitCode:  ExitCodeInvalidFlagError,
+		},
+		{
+			command:           "bump-reviewer notes -o shuheiktgw -r bump-reviewer",
+			expectedOutStream: "",
+			expectedErrStream: "Failed to set up bump-reviewer: GitHub Personal Access Token is missing\nPlease set it via `-t` option\n\n",
+			expectedExitCode:  ExitCodeInvalidFlagError,
+		},
+		{
+			command:           "bump-reviewer propose -o shuheiktgw -r bump-reviewer -t 1234abcd --kind huge",
+			expectedOutStream: "",
+			expectedErrStream: "Failed to set up bump-reviewer: unknown bump kind \"huge\"\nPlease set one of patch, minor or major via `--kind` option\n\n",
+			expectedExitCode:  ExitCodeInvalidFlagError,
 		},
 		{
 			command:           "bump-reviewer -v",
@@ -74,3 +97,23 @@ func TestCLI_Run(t *testing.T) {
 		}
 	}
 }
+
+func TestSkipExitCodes(t *testing.T) {
+	cases := []struct {
+		reason   string
+		expected int
+	}{
+		{reason: SkipLabel, expected: 6},
+		{reason: SkipDraft, expected: 8},
+		{reason: SkipClosed, expected: 9},
+		{reason: SkipMerged, expected: 10},
+		{reason: SkipBaseBranch, expected: 11},
+	}
+
+	for i, tc := range cases {
+		var err error = &skipError{Reason: tc.reason}
+		if got := skipExitCodes[err.(skip).reason()]; got != tc.expected {
+			t.Errorf("#%d skip reason %q exits with %d, want %d", i, tc.reason, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+const CheckCommits = "conventional commits"
+
+var (
+	conventionalHeaderRegex = regexp.MustCompile(`^(\w+)(?:\([^)]*\))?(!)?: \S`)
+	breakingFooterRegex     = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: `)
+)
+
+// reviewCommits checks if the PR bumps version enough for the Conventional Commits since the latest release
+func (r *Reviewer) reviewCommits(pkg *Package, commits []github.RepositoryCommit, result *ReviewResult) error {
+	required := BumpPatch
+	var reason string
+	for _, c := range commits {
+		message := c.GetCommit().GetMessage()
+		if kind := requiredBumpKind(message); bumpKindOrder[kind] > bumpKindOrder[required] {
+			required = kind
+			reason = strings.SplitN(message, "\n", 2)[0]
+		}
+	}
+
+	actual := bumpKindOf(r.versionScheme(pkg), result.Versions.Baseline, result.Versions.Found)
+
+	if bumpKindOrder[actual] < bumpKindOrder[required] {
+		return &reviewError{Check: CheckCommits, Message: fmt.Sprintf("Pull Request #%d bumps %s version, but the commits since the latest release %s need a %s version bump because of `%s`.", result.Number, actual, result.Release.Tag, required, reason)}
+	}
+
+	if pkg.config.ConventionalCommits.WarnLarger && bumpKindOrder[actual] > bumpKindOrder[required] {
+		result.warn(fmt.Sprintf("Pull Request #%d bumps %s version, but the commits since the latest release %s only need a %s version bump.", result.Number, actual, result.Release.Tag, required))
+	}
+
+	return nil
+}
+
+// requiredBumpKind returns the kind of version bump a Conventional Commit needs.
+// Commits which do not follow Conventional Commits need a patch version bump.
+func requiredBumpKind(message string) string {
+	header := strings.SplitN(message, "\n", 2)[0]
+	m := conventionalHeaderRegex.FindStringSubmatch(header)
+
+	if (m != nil && m[2] == "!") || breakingFooterRegex.MatchString(message) {
+		return BumpMajor
+	}
+
+	if m != nil && strings.ToLower(m[1]) == "feat" {
+		return BumpMinor
+	}
+
+	return BumpPatch
+}
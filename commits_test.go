@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestRequiredBumpKind(t *testing.T) {
+	cases := []struct {
+		message  string
+		expected string
+	}{
+		{message: "fix: handle empty version.rb", expected: BumpPatch},
+		{message: "Update README", expected: BumpPatch},
+		{message: "feat: add propose command", expected: BumpMinor},
+		{message: "feat(cli): add propose command\n\nRefs #12", expected: BumpMinor},
+		{message: "feat!: drop Ruby 2.3 support", expected: BumpMajor},
+		{message: "refactor(api)!: rename options", expected: BumpMajor},
+		{message: "fix: rename option\n\nBREAKING CHANGE: --token is renamed to --github-token", expected: BumpMajor},
+		{message: "fix: rename option\n\nBREAKING-CHANGE: --token is renamed", expected: BumpMajor},
+		{message: "docs: mention BREAKING CHANGE: in README", expected: BumpPatch},
+	}
+
+	for i, tc := range cases {
+		if got := requiredBumpKind(tc.message); got != tc.expected {
+			t.Errorf("#%d requiredBumpKind returned %s, want %s", i, got, tc.expected)
+		}
+	}
+}
+
+func TestReviewer_ReviewCommits(t *testing.T) {
+	cases := []struct {
+		messages   []string
+		found      string
+		warnLarger bool
+		rejected   bool
+		warnings   int
+	}{
+		{messages: []string{"fix: a bug", "chore: update deps"}, found: "1.0.2"},
+		{messages: []string{"fix: a bug", "feat: a feature"}, found: "1.0.2", rejected: true},
+		{messages: []string{"fix: a bug", "feat: a feature"}, found: "1.1.0"},
+		{messages: []string{"feat!: a breaking feature"}, found: "1.1.0", rejected: true},
+		{messages: []string{"fix: a bug"}, found: "2.0.0"},
+		{messages: []string{"fix: a bug"}, found: "2.0.0", warnLarger: true, warnings: 1},
+	}
+
+	for i, tc := range cases {
+		reviewer := Reviewer{GitHubClient: NewGitHubClient(testGitHubOwner, testGitHubRepo, testGitHubToken), Config: DefaultConfig()}
+		reviewer.Config.ConventionalCommits = ConventionalCommitsConfig{Enabled: true, WarnLarger: tc.warnLarger}
+
+		var commits []github.RepositoryCommit
+		for _, m := range tc.messages {
+			commits = append(commits, github.RepositoryCommit{Commit: &github.Commit{Message: github.String(m)}})
+		}
+
+		result := &ReviewResult{Number: 1, Release: ReleaseResult{Tag: "v1.0.1"}, Versions: versionTable{Baseline: "1.0.1", Found: tc.found}}
+		err := reviewer.reviewCommits(testPackage(&reviewer), commits, result)
+		if tc.rejected {
+			if re, ok := err.(*reviewError); !ok || re.Check != CheckCommits {
+				t.Errorf("#%d Reviewer.reviewCommits returned %v, want reviewError of %s check", i, err, CheckCommits)
+			}
+		} else if err != nil {
+			t.Errorf("#%d Reviewer.reviewCommits returned unexpected error: %s", i, err)
+		}
+
+		if got := len(result.Warnings); got != tc.warnings {
+			t.Errorf("#%d Reviewer.reviewCommits made %d warnings, want %d", i, got, tc.warnings)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/google/go-github/github"
+)
+
+const CheckConcurrent = "concurrent PRs"
+
+// What bump-reviewer does when other open PRs propose a conflicting version
+const (
+	ConcurrentFail = "fail"
+	ConcurrentWarn = "warn"
+)
+
+// reviewConcurrent checks if other open PRs to the same base branch propose a higher version,
+// or the same version with a lower number. The PR opened first wins a tie so that only one of them is blocked.
+func (r *Reviewer) reviewConcurrent(pr *github.PullRequest, pkg *Package, result *ReviewResult) error {
+	scheme := r.versionScheme(pkg)
+	found, err := scheme.normalize(result.Versions.Found)
+	if err != nil {
+		return err
+	}
+
+	opt := github.PullRequestListOptions{State: "open", Base: pr.GetBase().GetRef(), ListOptions: github.ListOptions{PerPage: 100}}
+	prs, err := r.ListPullRequests(&opt)
+	if err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	for _, other := range prs {
+		if other.GetNumber() == pr.GetNumber() {
+			continue
+		}
+
+		version, err := r.proposedVersion(pkg, other.GetNumber())
+		if err != nil {
+			return err
+		}
+
+		v, err := scheme.normalize(version)
+		if err != nil {
+			continue
+		}
+
+		c := scheme.compare(v, found)
+		if c < 0 || (c == 0 && other.GetNumber() > pr.GetNumber()) {
+			continue
+		}
+
+		fmt.Fprintf(&b, "\n- #%d proposes %s: %s", other.GetNumber(), version, other.GetHTMLURL())
+	}
+
+	if b.Len() == 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("Other open Pull Requests propose a higher version than %s, or the same version and were opened earlier.\n%s", result.Versions.Found, b.String())
+	if pkg.config.ConcurrentPullRequests == ConcurrentWarn {
+		result.warn(message)
+		return nil
+	}
+
+	return &reviewError{Check: CheckConcurrent, Message: message}
+}
+
+// proposedVersion returns the version of a package a PR proposes, or an empty string if it does not change its version.rb
+func (r *Reviewer) proposedVersion(pkg *Package, number int) (string, error) {
+	files, err := r.ListPullRequestsFiles(number, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return "", err
+	}
+
+	filename := pkg.Path
+	for _, f := range files {
+		if f.GetFilename() != filename {
+			continue
+		}
+
+		opt := github.RepositoryContentGetOptions{Ref: fmt.Sprintf("pull/%d/head", number)}
+		fc, _, err := r.GetContent(filename, &opt)
+		if err != nil {
+			return "", err
+		}
+
+		content, err := decodeContent(fc)
+		if err != nil {
+			return "", err
+		}
+
+		return pkg.parseVersion(content), nil
+	}
+
+	return "", nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestReviewer_ReviewConcurrent(t *testing.T) {
+	cases := []struct {
+		number     int
+		found      string
+		concurrent string
+		rejected   bool
+		warnings   int
+	}{
+		{number: 3, found: "1.1.0"},
+		{number: 3, found: "1.0.2", rejected: true},
+		{number: 3, found: "1.0.2", concurrent: ConcurrentWarn, warnings: 1},
+		{number: 1, found: "1.0.2"},
+		{number: 1, found: "1.0.1", rejected: true},
+	}
+
+	for i, tc := range cases {
+		reviewer, mux, _, tearDown := setupReviewer()
+
+		setPullRequestsHandler(mux, `[{"number":1},{"number":2,"html_url":"https://github.com/shuheiktgw/bump-reviewer/pull/2"},{"number":3}]`)
+		setPullRequestFilesHandler(mux, 2, `[{"filename":"lib/bump-reviewer/version.rb"}]`)
+		setPullRequestFilesHandler(mux, 1, `[{"filename":"lib/bump-reviewer/cli.rb"}]`)
+		setPullRequestFilesHandler(mux, 3, `[{"filename":"lib/bump-reviewer/cli.rb"}]`)
+		setVersionAtRefHandler(mux, map[string]string{"pull/2/head": "1.0.2"})
+
+		reviewer.Config = DefaultConfig()
+		if tc.concurrent != "" {
+			reviewer.Config.ConcurrentPullRequests = tc.concurrent
+		}
+
+		pr := &github.PullRequest{Number: github.Int(tc.number), Base: &github.PullRequestBranch{Ref: github.String("master")}}
+		result := &ReviewResult{Number: tc.number, Versions: versionTable{Found: tc.found}}
+
+		err := reviewer.reviewConcurrent(pr, testPackage(reviewer), result)
+		if tc.rejected {
+			re, ok := err.(*reviewError)
+			if !ok || re.Check != CheckConcurrent {
+				t.Errorf("#%d Reviewer.reviewConcurrent returned %v, want reviewError of %s check", i, err, CheckConcurrent)
+			} else if want := "- #2 proposes 1.0.2: https://github.com/shuheiktgw/bump-reviewer/pull/2"; !strings.Contains(re.Message, want) {
+				t.Errorf("#%d Reviewer.reviewConcurrent returned unexpected message: want: %s, got: %s", i, want, re.Message)
+			}
+		} else if err != nil {
+			t.Errorf("#%d Reviewer.reviewConcurrent returned unexpected error: %s", i, err)
+		}
+
+		if got := len(result.Warnings); got != tc.warnings {
+			t.Errorf("#%d Reviewer.reviewConcurrent made %d warnings, want %d", i, got, tc.warnings)
+		}
+
+		tearDown()
+	}
+}
@@ -0,0 +1,524 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"text/template"
+	"text/template/parse"
+	"time"
+)
+
+const (
+	defaultApprovalTemplate = `LGTM
+
+bump-reviewer checks the following points.
+{{range .Checks}}
+- {{.Description}}{{end}}
+{{range .Warnings}}
+> {{.}}
+{{end}}`
+
+	defaultFailureTemplate = `{{.Message}}`
+
+	defaultStickyTemplate = `{{if .Passed}}**bump-reviewer review passed** on {{.HeadSHA}}
+
+All the problems bump-reviewer reported before have been resolved.{{else}}**bump-reviewer review failed** on {{.HeadSHA}}
+
+{{.Message}}{{end}}`
+)
+
+// Config is a per repository configuration of bump-reviewer
+type Config struct {
+	Templates TemplatesConfig `json:"templates"`
+
+	Versioning VersioningConfig `json:"versioning"`
+
+	VersionFile VersionFileConfig `json:"version_file"`
+
+	Tag TagConfig `json:"tag"`
+
+	// BumpLabels maps PR labels to the kinds of version bumps they allow.
+	// PRs without any of the labels are allowed to bump patch version only.
+	BumpLabels map[string]string `json:"bump_labels"`
+
+	// ChangeLabels maps labels of the PRs merged since the latest release to the kinds of version bumps they need.
+	// PRs without any of the labels need a patch version bump.
+	ChangeLabels map[string]string `json:"change_labels"`
+
+	Authors AuthorsConfig `json:"authors"`
+
+	// BaseBranches are the branches, or glob patterns of them, bump PRs are allowed to target.
+	// Only the default branch of the repository is allowed if it is empty.
+	BaseBranches []string `json:"base_branches"`
+
+	// ConcurrentPullRequests is either "fail" or "warn", and decides what bump-reviewer does
+	// when other open PRs propose a higher version or the same one with a lower number. It is "fail" by default.
+	ConcurrentPullRequests string `json:"concurrent_pull_requests"`
+
+	Registry RegistryConfig `json:"registry"`
+
+	// MaintenanceBranches are glob patterns of the branches of older release lines such as "*-stable".
+	// PRs to them are compared to the latest release of the line instead of the latest release of the repository.
+	MaintenanceBranches []string `json:"maintenance_branches"`
+
+	CI CIConfig `json:"ci"`
+
+	ConventionalCommits ConventionalCommitsConfig `json:"conventional_commits"`
+
+	// Merge is used when bump-reviewer runs with --merge
+	Merge MergeConfig `json:"merge"`
+
+	ReleaseNotes ReleaseNotesConfig `json:"release_notes"`
+
+	// Packages are the gems of a repository which hosts several gems.
+	// The repository is a single gem if it is empty.
+	Packages []PackageConfig `json:"packages"`
+
+	// MultiplePackages is either "reject" or "review", and decides what bump-reviewer does
+	// when a PR bumps more than one package. It is "reject" by default.
+	MultiplePackages string `json:"multiple_packages"`
+
+	approval, failure, sticky *template.Template
+}
+
+// VersioningConfig is the versioning scheme of the repository
+type VersioningConfig struct {
+	// Scheme is either "semver" or "calver", and it is "semver" by default
+	Scheme string `json:"scheme"`
+
+	// Format is the format of calendar versions such as "YYYY.0M.0D" or "YYYY.MINOR.MICRO"
+	Format string `json:"format"`
+
+	tokens []string
+}
+
+// TagConfig configures the tags of releases
+type TagConfig struct {
+	// Template is a text/template of tags executed with Repo, Package and Version, such as "{{.Repo}}-v{{.Version}}".
+	// Without it, tags are "v" followed by the version, and tags prefixed with "v", "V" or nothing are recognized.
+	Template string `json:"template"`
+
+	template *template.Template
+}
+
+// AuthorsConfig restricts who can get an approval from bump-reviewer.
+// Everyone is allowed if neither Users nor Teams is specified.
+type AuthorsConfig struct {
+	Users []string `json:"users"`
+
+	// Teams are specified as "org/team-slug"
+	Teams []string `json:"teams"`
+
+	RejectForks bool `json:"reject_forks"`
+}
+
+func (a AuthorsConfig) enabled() bool {
+	return len(a.Users) != 0 || len(a.Teams) != 0 || a.RejectForks
+}
+
+// RegistryConfig makes bump-reviewer check a RubyGems compatible registry
+type RegistryConfig struct {
+	// URL is the base URL of the registry such as "https://rubygems.org".
+	// The registry is not checked if it is empty.
+	URL string `json:"url"`
+
+	// Gem is the name of the gem, which is the repository name by default
+	Gem string `json:"gem"`
+
+	// TokenEnv is the name of the environment variable which holds the value of Authorization header
+	TokenEnv string `json:"token_env"`
+
+	// Baseline makes bump-reviewer use the highest published version as the baseline
+	// if it is higher than the latest release
+	Baseline bool `json:"baseline"`
+}
+
+func (c RegistryConfig) enabled() bool {
+	return c.URL != ""
+}
+
+// CIConfig makes bump-reviewer wait for CI before approving
+type CIConfig struct {
+	// RequiredContexts are the names of commit statuses or check runs which need to succeed
+	RequiredContexts []string `json:"required_contexts"`
+
+	// Timeout and Interval are durations such as "10m" and "10s"
+	Timeout  string `json:"timeout"`
+	Interval string `json:"interval"`
+
+	timeout, interval time.Duration
+}
+
+// ConventionalCommitsConfig makes bump-reviewer require the version bump
+// the Conventional Commits since the latest release need
+type ConventionalCommitsConfig struct {
+	Enabled bool `json:"enabled"`
+
+	// WarnLarger makes bump-reviewer warn if the bump is larger than needed
+	WarnLarger bool `json:"warn_larger"`
+}
+
+// MergeConfig configures how bump-reviewer merges approved PRs
+type MergeConfig struct {
+	// Method is one of merge, squash or rebase
+	Method string `json:"method"`
+
+	// CommitTitle and CommitMessage are templates executed with a ReviewResult.
+	// GitHub's defaults are used if they are empty.
+	CommitTitle   string `json:"commit_title"`
+	CommitMessage string `json:"commit_message"`
+
+	// AutoMerge enables GitHub native auto-merge instead of merging right away
+	AutoMerge bool `json:"auto_merge"`
+
+	commitTitle, commitMessage *template.Template
+}
+
+// ReleaseNotesConfig configures the notes of the releases bump-reviewer creates
+type ReleaseNotesConfig struct {
+	// Sections group merged PRs by their labels in the given order.
+	// PRs which match none of them are listed in "Other changes".
+	Sections []NotesSection `json:"sections"`
+}
+
+// NotesSection is a section of release notes which lists PRs with any of the Labels
+type NotesSection struct {
+	Title  string   `json:"title"`
+	Labels []string `json:"labels"`
+}
+
+// TemplatesConfig holds text/template sources of the messages bump-reviewer posts.
+// Each template is executed with a ReviewResult.
+type TemplatesConfig struct {
+	Approval string `json:"approval"`
+	Failure  string `json:"failure"`
+	Sticky   string `json:"sticky"`
+}
+
+// DefaultConfig returns a Config with the default settings
+func DefaultConfig() *Config {
+	c := &Config{}
+	if err := c.init(); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// configOrDefault returns a given config, or DefaultConfig if it is nil
+func configOrDefault(c *Config) *Config {
+	if c == nil {
+		return DefaultConfig()
+	}
+
+	return c
+}
+
+// LoadConfig reads a JSON config file and validates it
+func LoadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Config
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.DisallowUnknownFields()
+	if err := d.Decode(&c); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", path, err)
+	}
+
+	if err := c.init(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %s", path, err)
+	}
+
+	return &c, nil
+}
+
+// clone returns a copy of the config which shares no maps or slices with it
+func (c *Config) clone() Config {
+	d := *c
+	d.BumpLabels = copyLabels(c.BumpLabels)
+	d.ChangeLabels = copyLabels(c.ChangeLabels)
+	d.Authors.Users = append([]string(nil), c.Authors.Users...)
+	d.Authors.Teams = append([]string(nil), c.Authors.Teams...)
+	d.BaseBranches = append([]string(nil), c.BaseBranches...)
+	d.MaintenanceBranches = append([]string(nil), c.MaintenanceBranches...)
+	d.CI.RequiredContexts = append([]string(nil), c.CI.RequiredContexts...)
+	d.Versioning.tokens = append([]string(nil), c.Versioning.tokens...)
+	d.Packages = append([]PackageConfig(nil), c.Packages...)
+
+	d.ReleaseNotes.Sections = nil
+	for _, section := range c.ReleaseNotes.Sections {
+		section.Labels = append([]string(nil), section.Labels...)
+		d.ReleaseNotes.Sections = append(d.ReleaseNotes.Sections, section)
+	}
+
+	return d
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	if labels == nil {
+		return nil
+	}
+
+	copied := make(map[string]string, len(labels))
+	for k, v := range labels {
+		copied[k] = v
+	}
+
+	return copied
+}
+
+// init fills in the defaults and compiles the templates
+func (c *Config) init() error {
+	if c.Templates.Approval == "" {
+		c.Templates.Approval = defaultApprovalTemplate
+	}
+	if c.Templates.Failure == "" {
+		c.Templates.Failure = defaultFailureTemplate
+	}
+	if c.Templates.Sticky == "" {
+		c.Templates.Sticky = defaultStickyTemplate
+	}
+
+	switch c.Versioning.Scheme {
+	case "":
+		c.Versioning.Scheme = SchemeSemVer
+	case SchemeSemVer:
+	case SchemeCalVer:
+		tokens, err := parseCalVerFormat(c.Versioning.Format)
+		if err != nil {
+			return fmt.Errorf("versioning.format is invalid: %s", err)
+		}
+		c.Versioning.tokens = tokens
+	default:
+		return fmt.Errorf("versioning.scheme must be either semver or calver: %q", c.Versioning.Scheme)
+	}
+
+	if c.VersionFile.Pattern != "" {
+		re, err := parseVersionPattern(c.VersionFile.Pattern)
+		if err != nil {
+			return err
+		}
+		c.VersionFile.pattern = re
+	}
+
+	if c.Tag.Template != "" {
+		t, err := parseTagTemplate(c.Tag.Template)
+		if err != nil {
+			return err
+		}
+		c.Tag.template = t
+	}
+
+	for label, kind := range c.BumpLabels {
+		if !validBumpKind(kind) {
+			return fmt.Errorf("bump_labels maps %q to unknown bump kind %q, it must be one of patch, minor or major", label, kind)
+		}
+	}
+
+	for label, kind := range c.ChangeLabels {
+		if !validBumpKind(kind) {
+			return fmt.Errorf("change_labels maps %q to unknown bump kind %q, it must be one of patch, minor or major", label, kind)
+		}
+	}
+
+	if c.Registry.enabled() {
+		if u, err := url.Parse(c.Registry.URL); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("registry.url must be an absolute URL: %q", c.Registry.URL)
+		}
+	}
+
+	switch c.ConcurrentPullRequests {
+	case "":
+		c.ConcurrentPullRequests = ConcurrentFail
+	case ConcurrentFail, ConcurrentWarn:
+	default:
+		return fmt.Errorf("concurrent_pull_requests must be either fail or warn: %q", c.ConcurrentPullRequests)
+	}
+
+	if c.CI.Timeout == "" {
+		c.CI.Timeout = "10m"
+	}
+	if c.CI.Interval == "" {
+		c.CI.Interval = "10s"
+	}
+
+	var err error
+	if c.CI.timeout, err = time.ParseDuration(c.CI.Timeout); err != nil {
+		return fmt.Errorf("ci.timeout is invalid: %s", err)
+	}
+	if c.CI.interval, err = time.ParseDuration(c.CI.Interval); err != nil || c.CI.interval <= 0 {
+		return fmt.Errorf("ci.interval must be a positive duration: %q", c.CI.Interval)
+	}
+
+	switch c.Merge.Method {
+	case "":
+		c.Merge.Method = MergeMethodMerge
+	case MergeMethodMerge, MergeMethodSquash, MergeMethodRebase:
+	default:
+		return fmt.Errorf("merge.method must be one of merge, squash or rebase: %q", c.Merge.Method)
+	}
+
+	for _, b := range c.BaseBranches {
+		if _, err := path.Match(b, ""); err != nil {
+			return fmt.Errorf("base_branches has an invalid pattern %q: %s", b, err)
+		}
+	}
+
+	for _, b := range c.MaintenanceBranches {
+		if _, err := path.Match(b, ""); err != nil {
+			return fmt.Errorf("maintenance_branches has an invalid pattern %q: %s", b, err)
+		}
+	}
+	if len(c.MaintenanceBranches) != 0 && c.Versioning.Scheme == SchemeCalVer {
+		return fmt.Errorf("maintenance_branches are only supported with semver, but versioning.scheme is calver")
+	}
+
+	for _, team := range c.Authors.Teams {
+		if org, slug := splitTeam(team); org == "" || slug == "" {
+			return fmt.Errorf("authors.teams has %q, but teams must be specified as org/team-slug", team)
+		}
+	}
+
+	for i, section := range c.ReleaseNotes.Sections {
+		if section.Title == "" || len(section.Labels) == 0 {
+			return fmt.Errorf("release_notes.sections[%d] must have a title and labels", i)
+		}
+	}
+
+	if c.approval, err = parseTemplate("approval", c.Templates.Approval); err != nil {
+		return err
+	}
+	if c.failure, err = parseTemplate("failure", c.Templates.Failure); err != nil {
+		return err
+	}
+	if c.sticky, err = parseTemplate("sticky", c.Templates.Sticky); err != nil {
+		return err
+	}
+	if c.Merge.commitTitle, err = parseTemplate("merge.commit_title", c.Merge.CommitTitle); err != nil {
+		return err
+	}
+	if c.Merge.commitMessage, err = parseTemplate("merge.commit_message", c.Merge.CommitMessage); err != nil {
+		return err
+	}
+
+	switch c.MultiplePackages {
+	case "":
+		c.MultiplePackages = MultiplePackagesReject
+	case MultiplePackagesReject, MultiplePackagesReview:
+	default:
+		return fmt.Errorf("multiple_packages must be either reject or review: %q", c.MultiplePackages)
+	}
+
+	// A review of several packages has no single release or versions, which only .Packages describes
+	if c.MultiplePackages == MultiplePackagesReview && len(c.Packages) != 0 {
+		for _, t := range []*template.Template{c.approval, c.failure, c.sticky, c.Merge.commitTitle, c.Merge.commitMessage} {
+			if field := packageResultField(t.Tree.Root, true); field != "" {
+				return fmt.Errorf("%s template refers to .%s, which is empty when a Pull Request bumps more than one package with multiple_packages review. Please use .Packages instead", t.Name(), field)
+			}
+		}
+	}
+
+	names, paths := map[string]bool{}, map[string]bool{}
+	for i := range c.Packages {
+		p := &c.Packages[i]
+		if err := p.init(c); err != nil {
+			return fmt.Errorf("packages[%d]: %s", i, err)
+		}
+		if names[p.Name] {
+			return fmt.Errorf("packages has %q more than once", p.Name)
+		}
+		if paths[p.Path] {
+			return fmt.Errorf("packages has more than one package at %s", p.Path)
+		}
+		names[p.Name], paths[p.Path] = true, true
+	}
+
+	return nil
+}
+
+// parseTemplate parses a template and executes it against a sample result,
+// so that references to unknown fields are rejected before any review runs
+func parseTemplate(name, text string) (*template.Template, error) {
+	t, err := template.New(name).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%s template is invalid: %s", name, err)
+	}
+
+	if err := t.Execute(ioutil.Discard, sampleReviewResult()); err != nil {
+		return nil, fmt.Errorf("%s template is invalid: %s", name, err)
+	}
+
+	return t, nil
+}
+
+// packageResultFields are the fields of a review result which describe a single package
+var packageResultFields = map[string]bool{"Release": true, "BumpKinds": true, "Versions": true, "RequiredBump": true}
+
+// packageResultField returns the first field of packageResultFields a template refers to on the review result.
+// Dot is something else, such as each of .Packages, in range and with blocks, where only $ refers to the result.
+func packageResultField(node parse.Node, root bool) string {
+	var nodes []parse.Node
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n != nil {
+			nodes = n.Nodes
+		}
+	case *parse.ActionNode:
+		nodes = []parse.Node{n.Pipe}
+	case *parse.PipeNode:
+		if n != nil {
+			for _, cmd := range n.Cmds {
+				nodes = append(nodes, cmd)
+			}
+		}
+	case *parse.CommandNode:
+		nodes = n.Args
+	case *parse.ChainNode:
+		nodes = []parse.Node{n.Node}
+	case *parse.TemplateNode:
+		nodes = []parse.Node{n.Pipe}
+	case *parse.IfNode:
+		nodes = []parse.Node{n.Pipe, n.List, n.ElseList}
+	case *parse.RangeNode:
+		if field := packageResultField(n.List, false); field != "" {
+			return field
+		}
+		nodes = []parse.Node{n.Pipe, n.ElseList}
+	case *parse.WithNode:
+		if field := packageResultField(n.List, false); field != "" {
+			return field
+		}
+		nodes = []parse.Node{n.Pipe, n.ElseList}
+	case *parse.FieldNode:
+		if root && packageResultFields[n.Ident[0]] {
+			return n.Ident[0]
+		}
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" && packageResultFields[n.Ident[1]] {
+			return n.Ident[1]
+		}
+	}
+
+	for _, child := range nodes {
+		if field := packageResultField(child, root); field != "" {
+			return field
+		}
+	}
+
+	return ""
+}
+
+func render(t *template.Template, result *ReviewResult) (string, error) {
+	var b bytes.Buffer
+	if err := t.Execute(&b, result); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
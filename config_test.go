@@ -0,0 +1,129 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	c, err := LoadConfig("testdata/config.json")
+	if err != nil {
+		t.Fatalf("LoadConfig returned unexpected error: %s", err)
+	}
+
+	result := sampleReviewResult()
+	result.Versions.Found = "1.0.2"
+
+	got, err := render(c.approval, result)
+	if err != nil {
+		t.Fatalf("render returned unexpected error: %s", err)
+	}
+
+	want := "LGTM, shuheiktgw bumped bump-reviewer to 1.0.2\n\n- [ ] Release 1.0.2 after https://github.com/shuheiktgw/bump-reviewer/releases/tag/v1.0.1"
+	if got != want {
+		t.Errorf("approval template rendered unexpected body: want: %s, got: %s", want, got)
+	}
+
+	if got, want := c.Templates.Sticky, defaultStickyTemplate; got != want {
+		t.Errorf("LoadConfig did not fill in the default sticky template: got: %s", got)
+	}
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	cases := []struct {
+		content  string
+		expected string
+	}{
+		{content: `{"templates":`, expected: "failed to parse"},
+		{content: `{"template":{}}`, expected: `unknown field "template"`},
+		{content: `{"templates":{"failure":"{{if .Passed}}"}}`, expected: "failure template is invalid"},
+		{content: `{"templates":{"sticky":"{{.Unknown}}"}}`, expected: "sticky template is invalid"},
+		{content: `{"versioning":{"scheme":"romver"}}`, expected: "versioning.scheme must be either semver or calver"},
+		{content: `{"versioning":{"scheme":"calver","format":"YYYY.WEEK"}}`, expected: "versioning.format is invalid"},
+		{content: `{"tag":{"template":"{{.Repo}}-{{.Tag}}"}}`, expected: "tag.template is invalid"},
+		{content: `{"tag":{"template":"{{.Repo}}"}}`, expected: "tag.template must have {{.Version}} exactly once"},
+		{content: `{"bump_labels":{"bump:huge":"huge"}}`, expected: "unknown bump kind"},
+		{content: `{"change_labels":{"breaking":"huge"}}`, expected: "unknown bump kind"},
+		{content: `{"authors":{"teams":["releasers"]}}`, expected: "org/team-slug"},
+		{content: `{"base_branches":["[master"]}`, expected: "invalid pattern"},
+		{content: `{"concurrent_pull_requests":"ignore"}`, expected: "concurrent_pull_requests must be either fail or warn"},
+		{content: `{"registry":{"url":"gems.example.com"}}`, expected: "registry.url must be an absolute URL"},
+		{content: `{"maintenance_branches":["[1-2-stable"]}`, expected: "maintenance_branches has an invalid pattern"},
+		{content: `{"maintenance_branches":["*-stable"],"versioning":{"scheme":"calver","format":"YYYY.MINOR.MICRO"}}`, expected: "maintenance_branches are only supported with semver"},
+		{content: `{"maintenance_branches":["*-stable"],"packages":[{"name":"gem-a","versioning":{"scheme":"calver","format":"YYYY.MINOR.MICRO"}}]}`, expected: "maintenance_branches are only supported with semver"},
+		{content: `{"ci":{"timeout":"ten minutes"}}`, expected: "ci.timeout is invalid"},
+		{content: `{"ci":{"interval":"0s"}}`, expected: "ci.interval must be a positive duration"},
+		{content: `{"merge":{"method":"fast-forward"}}`, expected: "merge.method must be one of"},
+		{content: `{"merge":{"commit_title":"{{.Nope}}"}}`, expected: "merge.commit_title template is invalid"},
+		{content: `{"release_notes":{"sections":[{"title":"Features"}]}}`, expected: "must have a title and labels"},
+		{content: `{"version_file":{"pattern":"(?P<version>[0-9.+"}}`, expected: "version_file.pattern is invalid"},
+		{content: `{"version_file":{"pattern":"VERSION = (.+)"}}`, expected: `version_file.pattern must have a named capture group "version"`},
+		{content: `{"multiple_packages":"split"}`, expected: "multiple_packages must be either reject or review"},
+		{content: `{"packages":[{"path":"gems/a/lib/a/version.rb"}]}`, expected: "packages[0]: name is required"},
+		{content: `{"packages":[{"name":"a","versioning":{"scheme":"romver"}}]}`, expected: "packages[0]: versioning.scheme must be"},
+		{content: `{"packages":[{"name":"a"},{"name":"a"}]}`, expected: `packages has "a" more than once`},
+		{content: `{"packages":[{"name":"a"},{"name":"b","path":"gems/a/lib/a/version.rb"}]}`, expected: "more than one package at"},
+		{content: `{"packages":[{"name":"a"}],"multiple_packages":"review","merge":{"commit_title":"Bump up to v{{.Versions.Found}}"}}`, expected: "merge.commit_title template refers to .Versions"},
+		{content: `{"packages":[{"name":"a"}],"multiple_packages":"review","templates":{"approval":"{{range .Packages}}{{$.Release.Tag}}{{end}}"}}`, expected: "approval template refers to .Release"},
+		{content: `{"packages":[{"name":"a"}],"multiple_packages":"review","templates":{"sticky":"{{if .BumpKinds}}ok{{end}}"}}`, expected: "sticky template refers to .BumpKinds"},
+	}
+
+	for i, tc := range cases {
+		f, err := ioutil.TempFile("", "bump-reviewer")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(f.Name())
+
+		f.WriteString(tc.content)
+		f.Close()
+
+		_, err = LoadConfig(f.Name())
+		if err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("#%d LoadConfig returned unexpected error: want: %s, got: %v", i, tc.expected, err)
+		}
+	}
+}
+
+func TestDefaultConfig_Approval(t *testing.T) {
+	got, err := render(DefaultConfig().approval, sampleReviewResult())
+	if err != nil {
+		t.Fatalf("render returned unexpected error: %s", err)
+	}
+
+	want := `LGTM
+
+bump-reviewer checks the following points.
+
+- PR changes only version.rb
+- PR increments patch version by one
+`
+	if got != want {
+		t.Errorf("default approval template rendered unexpected body: want: %s, got: %s", want, got)
+	}
+}
+
+func TestDefaultConfig_ApprovalWithWarnings(t *testing.T) {
+	result := sampleReviewResult()
+	result.warn("Pull Request #1 bumps major version, but the commits since the latest release v1.0.1 only need a patch version bump.")
+
+	got, err := render(DefaultConfig().approval, result)
+	if err != nil {
+		t.Fatalf("render returned unexpected error: %s", err)
+	}
+
+	want := `LGTM
+
+bump-reviewer checks the following points.
+
+- PR changes only version.rb
+- PR increments patch version by one
+
+> Pull Request #1 bumps major version, but the commits since the latest release v1.0.1 only need a patch version bump.
+`
+	if got != want {
+		t.Errorf("default approval template rendered unexpected body: want: %s, got: %s", want, got)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+const CheckExisting = "existing version"
+
+// reviewExisting checks if a version of a package does not exist as a tag, a release or a draft release
+func (r *Reviewer) reviewExisting(pkg *Package, version string) error {
+	tags, err := pkg.versionTags(version)
+	if err != nil {
+		return err
+	}
+
+	var collisions []string
+	for _, tag := range tags {
+		ref, err := r.GetRef("tags/" + tag)
+		if err != nil {
+			return err
+		}
+
+		if ref != nil {
+			collisions = append(collisions, fmt.Sprintf("tag `%s`", tag))
+		}
+	}
+
+	releases, err := r.ListReleases(&github.ListOptions{PerPage: 100})
+	if err != nil {
+		return err
+	}
+
+	for _, release := range releases {
+		for _, tag := range tags {
+			if release.GetTagName() != tag {
+				continue
+			}
+
+			kind := "release"
+			if release.GetDraft() {
+				kind = "draft release"
+			}
+			collision := fmt.Sprintf("%s `%s`", kind, tag)
+			if url := release.GetHTMLURL(); url != "" {
+				collision += " " + url
+			}
+			collisions = append(collisions, collision)
+		}
+	}
+
+	if len(collisions) == 0 {
+		return nil
+	}
+
+	return &reviewError{Check: CheckExisting, Message: fmt.Sprintf("Version %s already exists as %s. bump-reviewer expects a version which has never been released.", version, strings.Join(collisions, ", "))}
+}
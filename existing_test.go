@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestReviewer_ReviewExisting(t *testing.T) {
+	cases := []struct {
+		tag      string
+		releases string
+		expected string
+	}{
+		{releases: `[{"tag_name":"v1.0.1"}]`},
+		{tag: "v1.0.2", releases: `[]`, expected: "Version 1.0.2 already exists as tag `v1.0.2`."},
+		{tag: "1.0.2", releases: `[]`, expected: "Version 1.0.2 already exists as tag `1.0.2`."},
+		{releases: `[{"tag_name":"v1.0.2","draft":true,"html_url":"https://github.com/shuheiktgw/bump-reviewer/releases/tag/untagged-1"}]`, expected: "Version 1.0.2 already exists as draft release `v1.0.2` https://github.com/shuheiktgw/bump-reviewer/releases/tag/untagged-1."},
+		{tag: "v1.0.2", releases: `[{"tag_name":"v1.0.2"}]`, expected: "Version 1.0.2 already exists as tag `v1.0.2`, release `v1.0.2`."},
+	}
+
+	for i, tc := range cases {
+		reviewer, mux, _, tearDown := setupReviewer()
+
+		mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/refs/tags/", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+			tag := r.URL.Path[len(fmt.Sprintf("/repos/%s/%s/git/refs/tags/", testGitHubOwner, testGitHubRepo)):]
+			if tag != tc.tag {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"message":"Not Found"}`)
+				return
+			}
+			fmt.Fprintf(w, `{"ref":"refs/tags/%s","object":{"sha":"abc123"}}`, tag)
+		})
+		mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/releases", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, tc.releases)
+		})
+
+		err := reviewer.reviewExisting(testPackage(reviewer), "1.0.2")
+		if tc.expected == "" {
+			if err != nil {
+				t.Errorf("#%d Reviewer.reviewExisting returned unexpected error: %s", i, err)
+			}
+		} else {
+			want := tc.expected + " bump-reviewer expects a version which has never been released."
+			if re, ok := err.(*reviewError); !ok || re.Check != CheckExisting || re.Message != want {
+				t.Errorf("#%d Reviewer.reviewExisting returned %v, want %s", i, err, want)
+			}
+		}
+
+		tearDown()
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/google/go-github/github"
 	"golang.org/x/oauth2"
@@ -14,8 +16,13 @@ const (
 	ReviewRequestChange = "REQUEST_CHANGES"
 	ReviewComment       = "COMMENT"
 	ReviewPending       = "PENDING"
+
+	ReviewStateApproved = "APPROVED"
 )
 
+// mediaTypeDraftPreview is required to see if a PR is a draft
+const mediaTypeDraftPreview = "application/vnd.github.shadow-cat-preview+json"
+
 // GitHubClient is a clint to interact with Github API
 type GitHubClient struct {
 	Owner, Repo string
@@ -50,9 +57,74 @@ func (c *GitHubClient) CompareCommits(base, head string) (*github.CommitsCompari
 		return nil, fmt.Errorf("Repositories.CompareCommits returns invalid status: %s", res.Status)
 	}
 
+	// A comparison has at most 250 commits unless its commits are paginated
+	if cc.GetTotalCommits() > len(cc.Commits) {
+		commits, err := c.listComparedCommits(base, head, cc.GetTotalCommits())
+		if err != nil {
+			return nil, err
+		}
+		cc.Commits = commits
+	}
+
 	return cc, nil
 }
 
+// listComparedCommits lists all the commits between base and head page by page
+func (c *GitHubClient) listComparedCommits(base, head string, total int) ([]github.RepositoryCommit, error) {
+	var all []github.RepositoryCommit
+	for page := 1; len(all) < total; page++ {
+		req, err := c.Client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%v/%v/compare/%v...%v?per_page=100&page=%d", c.Owner, c.Repo, base, head, page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var cc github.CommitsComparison
+		res, err := c.Client.Do(context.TODO(), req, &cc)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("Repositories.CompareCommits returns invalid status: %s", res.Status)
+		}
+
+		if len(cc.Commits) == 0 {
+			break
+		}
+		all = append(all, cc.Commits...)
+	}
+
+	return all, nil
+}
+
+// ListCommits lists all commits of the repository, such as the ones which change a path
+func (c *GitHubClient) ListCommits(opt *github.CommitsListOptions) ([]*github.RepositoryCommit, error) {
+	if opt == nil {
+		opt = &github.CommitsListOptions{}
+	}
+	page := *opt
+
+	var all []*github.RepositoryCommit
+	for {
+		rcs, res, err := c.Client.Repositories.ListCommits(context.TODO(), c.Owner, c.Repo, &page)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("Repositories.ListCommits returns invalid status: %s", res.Status)
+		}
+
+		all = append(all, rcs...)
+		if res.NextPage == 0 {
+			return all, nil
+		}
+		page.Page = res.NextPage
+	}
+}
+
 // GetPullRequestFiles gets files edited by a PR
 func (c *GitHubClient) ListPullRequestsFiles(number int, opt *github.ListOptions) ([]*github.CommitFile, error) {
 	cf, res, err := c.Client.PullRequests.ListFiles(context.TODO(), c.Owner, c.Repo, number, opt)
@@ -112,3 +184,564 @@ func (c *GitHubClient) CreateReview(number int, review *github.PullRequestReview
 
 	return prr, nil
 }
+
+// ListReviews lists reviews on a given PR
+func (c *GitHubClient) ListReviews(number int, opt *github.ListOptions) ([]*github.PullRequestReview, error) {
+	if opt == nil {
+		opt = &github.ListOptions{}
+	}
+	page := *opt
+
+	var all []*github.PullRequestReview
+	for {
+		prrs, res, err := c.Client.PullRequests.ListReviews(context.TODO(), c.Owner, c.Repo, number, &page)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("PullRequests.ListReviews returns invalid status: %s", res.Status)
+		}
+
+		all = append(all, prrs...)
+		if res.NextPage == 0 {
+			return all, nil
+		}
+		page.Page = res.NextPage
+	}
+}
+
+// DismissReview dismisses a review on a given PR
+func (c *GitHubClient) DismissReview(number int, reviewID int64, review *github.PullRequestReviewDismissalRequest) (*github.PullRequestReview, error) {
+	prr, res, err := c.Client.PullRequests.DismissReview(context.TODO(), c.Owner, c.Repo, number, reviewID, review)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("PullRequests.DismissReview returns invalid status: %s", res.Status)
+	}
+
+	return prr, nil
+}
+
+// GetAuthenticatedUser gets the user who owns the access token along with the token's OAuth scopes.
+// The scopes are nil if GitHub does not tell them, which is the case for GitHub App tokens.
+func (c *GitHubClient) GetAuthenticatedUser() (*github.User, []string, error) {
+	u, res, err := c.Client.Users.Get(context.TODO(), "")
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("Users.Get returns invalid status: %s", res.Status)
+	}
+
+	header, ok := res.Header["X-Oauth-Scopes"]
+	if !ok {
+		return u, nil, nil
+	}
+
+	scopes := []string{}
+	for _, h := range header {
+		for _, s := range strings.Split(h, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				scopes = append(scopes, s)
+			}
+		}
+	}
+
+	return u, scopes, nil
+}
+
+// GetPullRequest gets a given PR
+func (c *GitHubClient) GetPullRequest(number int) (*github.PullRequest, error) {
+	pr, res, err := c.Client.PullRequests.Get(context.TODO(), c.Owner, c.Repo, number)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("PullRequests.Get returns invalid status: %s", res.Status)
+	}
+
+	return pr, nil
+}
+
+// ListPullRequests lists all PRs of the repository
+func (c *GitHubClient) ListPullRequests(opt *github.PullRequestListOptions) ([]*github.PullRequest, error) {
+	if opt == nil {
+		opt = &github.PullRequestListOptions{}
+	}
+	page := *opt
+
+	var all []*github.PullRequest
+	for {
+		prs, res, err := c.Client.PullRequests.List(context.TODO(), c.Owner, c.Repo, &page)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("PullRequests.List returns invalid status: %s", res.Status)
+		}
+
+		all = append(all, prs...)
+		if res.NextPage == 0 {
+			return all, nil
+		}
+		page.Page = res.NextPage
+	}
+}
+
+// ListIssueComments lists all comments on a given issue or PR
+func (c *GitHubClient) ListIssueComments(number int, opt *github.IssueListCommentsOptions) ([]*github.IssueComment, error) {
+	if opt == nil {
+		opt = &github.IssueListCommentsOptions{}
+	}
+	page := *opt
+
+	var all []*github.IssueComment
+	for {
+		ics, res, err := c.Client.Issues.ListComments(context.TODO(), c.Owner, c.Repo, number, &page)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("Issues.ListComments returns invalid status: %s", res.Status)
+		}
+
+		all = append(all, ics...)
+		if res.NextPage == 0 {
+			return all, nil
+		}
+		page.Page = res.NextPage
+	}
+}
+
+// CreateIssueComment creates a comment on a given issue or PR
+func (c *GitHubClient) CreateIssueComment(number int, comment *github.IssueComment) (*github.IssueComment, error) {
+	ic, res, err := c.Client.Issues.CreateComment(context.TODO(), c.Owner, c.Repo, number, comment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("Issues.CreateComment returns invalid status: %s", res.Status)
+	}
+
+	return ic, nil
+}
+
+// EditIssueComment edits a given comment
+func (c *GitHubClient) EditIssueComment(commentID int64, comment *github.IssueComment) (*github.IssueComment, error) {
+	ic, res, err := c.Client.Issues.EditComment(context.TODO(), c.Owner, c.Repo, commentID, comment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Issues.EditComment returns invalid status: %s", res.Status)
+	}
+
+	return ic, nil
+}
+
+// GetTeamBySlug returns the team of a given org and slug, or nil if it is not found
+func (c *GitHubClient) GetTeamBySlug(org, slug string) (*github.Team, error) {
+	req, err := c.Client.NewRequest(http.MethodGet, fmt.Sprintf("orgs/%v/teams/%v", org, slug), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var team github.Team
+	res, err := c.Client.Do(context.TODO(), req, &team)
+
+	if isNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Teams.GetTeamBySlug returns invalid status: %s", res.Status)
+	}
+
+	return &team, nil
+}
+
+// IsTeamMember checks if a given user is a member of a given team
+func (c *GitHubClient) IsTeamMember(teamID int64, user string) (bool, error) {
+	member, _, err := c.Client.Teams.IsTeamMember(context.TODO(), teamID, user)
+
+	if err != nil {
+		return false, err
+	}
+
+	return member, nil
+}
+
+// IsDraftPullRequest checks if a given PR is a draft
+func (c *GitHubClient) IsDraftPullRequest(number int) (bool, error) {
+	req, err := c.Client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%v/%v/pulls/%d", c.Owner, c.Repo, number), nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", mediaTypeDraftPreview)
+
+	var pr struct {
+		Draft bool `json:"draft"`
+	}
+	res, err := c.Client.Do(context.TODO(), req, &pr)
+
+	if err != nil {
+		return false, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("PullRequests.Get returns invalid status: %s", res.Status)
+	}
+
+	return pr.Draft, nil
+}
+
+// GetCombinedStatus gets the combined commit status of a given ref
+func (c *GitHubClient) GetCombinedStatus(ref string, opt *github.ListOptions) (*github.CombinedStatus, error) {
+	cs, res, err := c.Client.Repositories.GetCombinedStatus(context.TODO(), c.Owner, c.Repo, ref, opt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Repositories.GetCombinedStatus returns invalid status: %s", res.Status)
+	}
+
+	return cs, nil
+}
+
+// ListCheckRunsForRef lists check runs of a given ref
+func (c *GitHubClient) ListCheckRunsForRef(ref string, opt *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, error) {
+	cr, res, err := c.Client.Checks.ListCheckRunsForRef(context.TODO(), c.Owner, c.Repo, ref, opt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Checks.ListCheckRunsForRef returns invalid status: %s", res.Status)
+	}
+
+	return cr, nil
+}
+
+// MergePullRequest merges a given PR
+func (c *GitHubClient) MergePullRequest(number int, commitMessage string, opt *github.PullRequestOptions) (*github.PullRequestMergeResult, error) {
+	mr, res, err := c.Client.PullRequests.Merge(context.TODO(), c.Owner, c.Repo, number, commitMessage, opt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("PullRequests.Merge returns invalid status: %s", res.Status)
+	}
+
+	return mr, nil
+}
+
+// EnableAutoMerge enables GitHub native auto-merge on a given PR through the GraphQL API.
+// The PR is merged only if its head is still expectedHeadSHA.
+func (c *GitHubClient) EnableAutoMerge(nodeID, method, expectedHeadSHA, commitTitle, commitMessage string) error {
+	query := `mutation($id: ID!, $method: PullRequestMergeMethod!, $sha: GitObjectID!, $title: String, $body: String) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method, expectedHeadOid: $sha, commitHeadline: $title, commitBody: $body}) {
+    clientMutationId
+  }
+}`
+	variables := map[string]interface{}{
+		"id":     nodeID,
+		"method": strings.ToUpper(method),
+		"sha":    expectedHeadSHA,
+	}
+	if commitTitle != "" {
+		variables["title"] = commitTitle
+	}
+	if commitMessage != "" {
+		variables["body"] = commitMessage
+	}
+
+	req, err := c.Client.NewRequest(http.MethodPost, graphQLURL(c.Client.BaseURL), map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+
+	var gr struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	res, err := c.Client.Do(context.TODO(), req, &gr)
+
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("enablePullRequestAutoMerge returns invalid status: %s", res.Status)
+	}
+
+	if len(gr.Errors) != 0 {
+		return fmt.Errorf("enablePullRequestAutoMerge returns an error: %s", gr.Errors[0].Message)
+	}
+
+	return nil
+}
+
+// graphQLURL returns the GraphQL endpoint of a REST API base URL.
+// It is /graphql on GitHub.com, but /api/graphql instead of /api/v3/graphql on GitHub Enterprise.
+func graphQLURL(base *url.URL) string {
+	u := *base
+	if strings.HasSuffix(u.Path, "/api/v3/") {
+		u.Path = strings.TrimSuffix(u.Path, "v3/") + "graphql"
+	} else {
+		u.Path += "graphql"
+	}
+
+	return u.String()
+}
+
+// GetTag gets an annotated tag object of a given SHA
+func (c *GitHubClient) GetTag(sha string) (*github.Tag, error) {
+	t, res, err := c.Client.Git.GetTag(context.TODO(), c.Owner, c.Repo, sha)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Git.GetTag returns invalid status: %s", res.Status)
+	}
+
+	return t, nil
+}
+
+// GetRef gets a given reference such as "tags/v1.0.0". It returns nil if the reference does not exist.
+func (c *GitHubClient) GetRef(ref string) (*github.Reference, error) {
+	r, res, err := c.Client.Git.GetRef(context.TODO(), c.Owner, c.Repo, ref)
+
+	if isNotFound(err) {
+		return nil, nil
+	}
+
+	// GitHub returns references which start with ref if there is no exact match
+	if err != nil && err.Error() == "no exact match found for this ref" {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Git.GetRef returns invalid status: %s", res.Status)
+	}
+
+	return r, nil
+}
+
+// CreateRef creates a reference
+func (c *GitHubClient) CreateRef(ref *github.Reference) (*github.Reference, error) {
+	r, res, err := c.Client.Git.CreateRef(context.TODO(), c.Owner, c.Repo, ref)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("Git.CreateRef returns invalid status: %s", res.Status)
+	}
+
+	return r, nil
+}
+
+// GetReleaseByTag gets the release of a given tag. It returns nil if the release does not exist.
+func (c *GitHubClient) GetReleaseByTag(tag string) (*github.RepositoryRelease, error) {
+	rr, res, err := c.Client.Repositories.GetReleaseByTag(context.TODO(), c.Owner, c.Repo, tag)
+
+	if isNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Repositories.GetReleaseByTag returns invalid status: %s", res.Status)
+	}
+
+	return rr, nil
+}
+
+// ListReleases lists all releases including drafts, which are visible to users with push access
+func (c *GitHubClient) ListReleases(opt *github.ListOptions) ([]*github.RepositoryRelease, error) {
+	if opt == nil {
+		opt = &github.ListOptions{}
+	}
+	page := *opt
+
+	var all []*github.RepositoryRelease
+	for {
+		rr, res, err := c.Client.Repositories.ListReleases(context.TODO(), c.Owner, c.Repo, &page)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("Repositories.ListReleases returns invalid status: %s", res.Status)
+		}
+
+		all = append(all, rr...)
+		if res.NextPage == 0 {
+			return all, nil
+		}
+		page.Page = res.NextPage
+	}
+}
+
+// CreateRelease creates a release. A release which is not latest does not replace the latest release of the repository.
+func (c *GitHubClient) CreateRelease(release *github.RepositoryRelease, latest bool) (*github.RepositoryRelease, error) {
+	// go-github does not support make_latest yet
+	body := struct {
+		*github.RepositoryRelease
+		MakeLatest string `json:"make_latest,omitempty"`
+	}{RepositoryRelease: release}
+	if !latest {
+		body.MakeLatest = "false"
+	}
+
+	req, err := c.Client.NewRequest(http.MethodPost, fmt.Sprintf("repos/%v/%v/releases", c.Owner, c.Repo), body)
+	if err != nil {
+		return nil, err
+	}
+
+	rr := new(github.RepositoryRelease)
+	res, err := c.Client.Do(context.TODO(), req, rr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("Repositories.CreateRelease returns invalid status: %s", res.Status)
+	}
+
+	return rr, nil
+}
+
+// GetRepository gets the repository
+func (c *GitHubClient) GetRepository() (*github.Repository, error) {
+	r, res, err := c.Client.Repositories.Get(context.TODO(), c.Owner, c.Repo)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Repositories.Get returns invalid status: %s", res.Status)
+	}
+
+	return r, nil
+}
+
+// GetCommit gets a commit object of the Git Data API
+func (c *GitHubClient) GetCommit(sha string) (*github.Commit, error) {
+	commit, res, err := c.Client.Git.GetCommit(context.TODO(), c.Owner, c.Repo, sha)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Git.GetCommit returns invalid status: %s", res.Status)
+	}
+
+	return commit, nil
+}
+
+// CreateTree creates a tree on top of a given base tree
+func (c *GitHubClient) CreateTree(baseTree string, entries []github.TreeEntry) (*github.Tree, error) {
+	t, res, err := c.Client.Git.CreateTree(context.TODO(), c.Owner, c.Repo, baseTree, entries)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("Git.CreateTree returns invalid status: %s", res.Status)
+	}
+
+	return t, nil
+}
+
+// CreateCommit creates a commit object of the Git Data API
+func (c *GitHubClient) CreateCommit(commit *github.Commit) (*github.Commit, error) {
+	cm, res, err := c.Client.Git.CreateCommit(context.TODO(), c.Owner, c.Repo, commit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("Git.CreateCommit returns invalid status: %s", res.Status)
+	}
+
+	return cm, nil
+}
+
+// CreatePullRequest opens a PR
+func (c *GitHubClient) CreatePullRequest(pull *github.NewPullRequest) (*github.PullRequest, error) {
+	pr, res, err := c.Client.PullRequests.Create(context.TODO(), c.Owner, c.Repo, pull)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("PullRequests.Create returns invalid status: %s", res.Status)
+	}
+
+	return pr, nil
+}
+
+// AddLabelsToIssue adds labels to an issue or a PR
+func (c *GitHubClient) AddLabelsToIssue(number int, labels []string) ([]*github.Label, error) {
+	l, res, err := c.Client.Issues.AddLabelsToIssue(context.TODO(), c.Owner, c.Repo, number, labels)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Issues.AddLabelsToIssue returns invalid status: %s", res.Status)
+	}
+
+	return l, nil
+}
+
+func isNotFound(err error) bool {
+	er, ok := err.(*github.ErrorResponse)
+	return ok && er.Response.StatusCode == http.StatusNotFound
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"reflect"
 	"testing"
 
@@ -32,6 +33,69 @@ func TestGitHubClient_CompareCommits(t *testing.T) {
 	}
 }
 
+func TestGitHubClient_CompareCommits_Paginated(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/compare/v1.0.0...master", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			fmt.Fprint(w, `{"total_commits":3,"commits":[{"sha":"a"}],"files":[{"filename":"README.md"}]}`)
+		case "1":
+			fmt.Fprint(w, `{"total_commits":3,"commits":[{"sha":"a"},{"sha":"b"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"total_commits":3,"commits":[{"sha":"c"}]}`)
+		default:
+			t.Errorf("GitHubClient.CompareCommits requested unexpected page: %s", r.URL.RawQuery)
+		}
+	})
+
+	cc, err := client.CompareCommits("v1.0.0", "master")
+	if err != nil {
+		t.Fatalf("GitHubClient.CompareCommits returned unexpected error: %v", err)
+	}
+
+	var shas []string
+	for _, c := range cc.Commits {
+		shas = append(shas, c.GetSHA())
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(shas, want) {
+		t.Errorf("GitHubClient.CompareCommits returned commits %v, want %v", shas, want)
+	}
+
+	if len(cc.Files) != 1 {
+		t.Errorf("GitHubClient.CompareCommits returned %d files, want 1", len(cc.Files))
+	}
+}
+
+func TestGitHubClient_ListCommits(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	u := fmt.Sprintf("/repos/%s/%s/commits", testGitHubOwner, testGitHubRepo)
+
+	mux.HandleFunc(u, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"sha":"abc122"}]`)
+			return
+		}
+		testFormValues(t, r, values{"sha": "master", "path": "gems/gem-a"})
+		w.Header().Set("Link", fmt.Sprintf(`<%s?page=2>; rel="next"`, u))
+		fmt.Fprint(w, `[{"sha":"abc123"}]`)
+	})
+
+	rcs, err := client.ListCommits(&github.CommitsListOptions{SHA: "master", Path: "gems/gem-a"})
+	if err != nil {
+		t.Fatalf("GitHubClient.ListCommits returned unexpected error: %v", err)
+	}
+
+	want := []*github.RepositoryCommit{{SHA: github.String("abc123")}, {SHA: github.String("abc122")}}
+	if !reflect.DeepEqual(rcs, want) {
+		t.Errorf("GitHubClient.ListCommits returned %+v, want %+v", rcs, want)
+	}
+}
+
 func TestGitHubClient_ListPullRequestsFiles(t *testing.T) {
 	client, mux, _, tearDown := setup()
 	defer tearDown()
@@ -123,3 +187,687 @@ func TestGitHubClient_CreateReview(t *testing.T) {
 		t.Errorf("GitHubClient.PullRequestReviewRequest returned %+v, want %+v", prr, want)
 	}
 }
+
+func TestGitHubClient_ListReviews(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	number := 3
+	u := fmt.Sprintf("/repos/%v/%v/pulls/%d/reviews", testGitHubOwner, testGitHubRepo, number)
+
+	mux.HandleFunc(u, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"id":2,"state":"DISMISSED"}]`)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s?page=2>; rel="next"`, u))
+		fmt.Fprint(w, `[{"id":1,"state":"APPROVED"}]`)
+	})
+
+	prrs, err := client.ListReviews(number, nil)
+	if err != nil {
+		t.Fatalf("GitHubClient.ListReviews returned unexpected error: %v", err)
+	}
+
+	want := []*github.PullRequestReview{{ID: github.Int64(1), State: github.String(ReviewStateApproved)}, {ID: github.Int64(2), State: github.String("DISMISSED")}}
+	if !reflect.DeepEqual(prrs, want) {
+		t.Errorf("GitHubClient.ListReviews returned %+v, want %+v", prrs, want)
+	}
+}
+
+func TestGitHubClient_DismissReview(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	number := 3
+	u := fmt.Sprintf("/repos/%v/%v/pulls/%d/reviews/%d/dismissals", testGitHubOwner, testGitHubRepo, number, 1)
+
+	mux.HandleFunc(u, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		testBody(t, r, `{"message":"Dismissed"}`+"\n")
+		fmt.Fprint(w, `{"id":1,"state":"DISMISSED"}`)
+	})
+
+	dismissal := github.PullRequestReviewDismissalRequest{Message: github.String("Dismissed")}
+	prr, err := client.DismissReview(number, 1, &dismissal)
+	if err != nil {
+		t.Fatalf("GitHubClient.DismissReview returned unexpected error: %v", err)
+	}
+
+	want := &github.PullRequestReview{ID: github.Int64(1), State: github.String("DISMISSED")}
+	if !reflect.DeepEqual(prr, want) {
+		t.Errorf("GitHubClient.DismissReview returned %+v, want %+v", prr, want)
+	}
+}
+
+func TestGitHubClient_GetAuthenticatedUser(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		w.Header().Set("X-OAuth-Scopes", "repo, read:org")
+		fmt.Fprint(w, `{"login":"shuheiktgw"}`)
+	})
+
+	u, scopes, err := client.GetAuthenticatedUser()
+	if err != nil {
+		t.Fatalf("GitHubClient.GetAuthenticatedUser returned unexpected error: %v", err)
+	}
+
+	want := &github.User{Login: github.String("shuheiktgw")}
+	if !reflect.DeepEqual(u, want) {
+		t.Errorf("GitHubClient.GetAuthenticatedUser returned %+v, want %+v", u, want)
+	}
+
+	if want := []string{"repo", "read:org"}; !reflect.DeepEqual(scopes, want) {
+		t.Errorf("GitHubClient.GetAuthenticatedUser returned scopes %v, want %v", scopes, want)
+	}
+}
+
+func TestGitHubClient_GetPullRequest(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	number := 3
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/pulls/%d", testGitHubOwner, testGitHubRepo, number), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"number":3,"head":{"sha":"abc123"}}`)
+	})
+
+	pr, err := client.GetPullRequest(number)
+	if err != nil {
+		t.Fatalf("GitHubClient.GetPullRequest returned unexpected error: %v", err)
+	}
+
+	want := &github.PullRequest{Number: github.Int(3), Head: &github.PullRequestBranch{SHA: github.String("abc123")}}
+	if !reflect.DeepEqual(pr, want) {
+		t.Errorf("GitHubClient.GetPullRequest returned %+v, want %+v", pr, want)
+	}
+}
+
+func TestGitHubClient_ListPullRequests(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	u := fmt.Sprintf("/repos/%v/%v/pulls", testGitHubOwner, testGitHubRepo)
+
+	mux.HandleFunc(u, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if r.URL.Query().Get("page") == "2" {
+			testFormValues(t, r, values{"state": "open", "base": "master", "page": "2"})
+			fmt.Fprint(w, `[{"number":4}]`)
+			return
+		}
+		testFormValues(t, r, values{"state": "open", "base": "master"})
+		w.Header().Set("Link", fmt.Sprintf(`<%s?page=2>; rel="next"`, u))
+		fmt.Fprint(w, `[{"number":3}]`)
+	})
+
+	prs, err := client.ListPullRequests(&github.PullRequestListOptions{State: "open", Base: "master"})
+	if err != nil {
+		t.Fatalf("GitHubClient.ListPullRequests returned unexpected error: %v", err)
+	}
+
+	want := []*github.PullRequest{{Number: github.Int(3)}, {Number: github.Int(4)}}
+	if !reflect.DeepEqual(prs, want) {
+		t.Errorf("GitHubClient.ListPullRequests returned %+v, want %+v", prs, want)
+	}
+}
+
+func TestGitHubClient_ListIssueComments(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	number := 3
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/issues/%d/comments", testGitHubOwner, testGitHubRepo, number), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `[{"id":1,"body":"Hello"}]`)
+	})
+
+	ics, err := client.ListIssueComments(number, nil)
+	if err != nil {
+		t.Fatalf("GitHubClient.ListIssueComments returned unexpected error: %v", err)
+	}
+
+	want := []*github.IssueComment{{ID: github.Int64(1), Body: github.String("Hello")}}
+	if !reflect.DeepEqual(ics, want) {
+		t.Errorf("GitHubClient.ListIssueComments returned %+v, want %+v", ics, want)
+	}
+}
+
+func TestGitHubClient_CreateIssueComment(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	number := 3
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/issues/%d/comments", testGitHubOwner, testGitHubRepo, number), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, `{"body":"Hello"}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":1,"body":"Hello"}`)
+	})
+
+	ic, err := client.CreateIssueComment(number, &github.IssueComment{Body: github.String("Hello")})
+	if err != nil {
+		t.Fatalf("GitHubClient.CreateIssueComment returned unexpected error: %v", err)
+	}
+
+	want := &github.IssueComment{ID: github.Int64(1), Body: github.String("Hello")}
+	if !reflect.DeepEqual(ic, want) {
+		t.Errorf("GitHubClient.CreateIssueComment returned %+v, want %+v", ic, want)
+	}
+}
+
+func TestGitHubClient_EditIssueComment(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/issues/comments/%d", testGitHubOwner, testGitHubRepo, 1), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPatch)
+		testBody(t, r, `{"body":"Edited"}`+"\n")
+		fmt.Fprint(w, `{"id":1,"body":"Edited"}`)
+	})
+
+	ic, err := client.EditIssueComment(1, &github.IssueComment{Body: github.String("Edited")})
+	if err != nil {
+		t.Fatalf("GitHubClient.EditIssueComment returned unexpected error: %v", err)
+	}
+
+	want := &github.IssueComment{ID: github.Int64(1), Body: github.String("Edited")}
+	if !reflect.DeepEqual(ic, want) {
+		t.Errorf("GitHubClient.EditIssueComment returned %+v, want %+v", ic, want)
+	}
+}
+
+func TestGitHubClient_GetTeamBySlug(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc("/orgs/shuheiktgw/teams/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if r.URL.Path != "/orgs/shuheiktgw/teams/maintainers" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"Not Found"}`)
+			return
+		}
+		fmt.Fprint(w, `{"id":1,"slug":"maintainers"}`)
+	})
+
+	team, err := client.GetTeamBySlug("shuheiktgw", "maintainers")
+	if err != nil {
+		t.Fatalf("GitHubClient.GetTeamBySlug returned unexpected error: %v", err)
+	}
+
+	want := &github.Team{ID: github.Int64(1), Slug: github.String("maintainers")}
+	if !reflect.DeepEqual(team, want) {
+		t.Errorf("GitHubClient.GetTeamBySlug returned %+v, want %+v", team, want)
+	}
+
+	team, err = client.GetTeamBySlug("shuheiktgw", "missing")
+	if err != nil || team != nil {
+		t.Errorf("GitHubClient.GetTeamBySlug returned %+v, %v, want nil", team, err)
+	}
+}
+
+func TestGitHubClient_IsTeamMember(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc("/teams/1/members/shuheiktgw", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	member, err := client.IsTeamMember(1, "shuheiktgw")
+	if err != nil {
+		t.Fatalf("GitHubClient.IsTeamMember returned unexpected error: %v", err)
+	}
+
+	if !member {
+		t.Errorf("GitHubClient.IsTeamMember returned false, want true")
+	}
+
+	member, err = client.IsTeamMember(1, "outsider")
+	if err != nil {
+		t.Fatalf("GitHubClient.IsTeamMember returned unexpected error: %v", err)
+	}
+
+	if member {
+		t.Errorf("GitHubClient.IsTeamMember returned true, want false")
+	}
+}
+
+func TestGitHubClient_IsDraftPullRequest(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	number := 3
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/pulls/%d", testGitHubOwner, testGitHubRepo, number), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if got, want := r.Header.Get("Accept"), mediaTypeDraftPreview; got != want {
+			t.Errorf("Accept header: %v, want %v", got, want)
+		}
+		fmt.Fprint(w, `{"number":3,"draft":true}`)
+	})
+
+	draft, err := client.IsDraftPullRequest(number)
+	if err != nil {
+		t.Fatalf("GitHubClient.IsDraftPullRequest returned unexpected error: %v", err)
+	}
+
+	if !draft {
+		t.Errorf("GitHubClient.IsDraftPullRequest returned false, want true")
+	}
+}
+
+func TestGitHubClient_GetCombinedStatus(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/commits/%v/status", testGitHubOwner, testGitHubRepo, "abc123"), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"state":"success"}`)
+	})
+
+	cs, err := client.GetCombinedStatus("abc123", nil)
+	if err != nil {
+		t.Fatalf("GitHubClient.GetCombinedStatus returned unexpected error: %v", err)
+	}
+
+	want := &github.CombinedStatus{State: github.String("success")}
+	if !reflect.DeepEqual(cs, want) {
+		t.Errorf("GitHubClient.GetCombinedStatus returned %+v, want %+v", cs, want)
+	}
+}
+
+func TestGitHubClient_ListCheckRunsForRef(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/commits/%v/check-runs", testGitHubOwner, testGitHubRepo, "abc123"), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"total_count":1,"check_runs":[{"name":"rspec"}]}`)
+	})
+
+	cr, err := client.ListCheckRunsForRef("abc123", nil)
+	if err != nil {
+		t.Fatalf("GitHubClient.ListCheckRunsForRef returned unexpected error: %v", err)
+	}
+
+	want := &github.ListCheckRunsResults{Total: github.Int(1), CheckRuns: []*github.CheckRun{{Name: github.String("rspec")}}}
+	if !reflect.DeepEqual(cr, want) {
+		t.Errorf("GitHubClient.ListCheckRunsForRef returned %+v, want %+v", cr, want)
+	}
+}
+
+func TestGitHubClient_MergePullRequest(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	number := 3
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/pulls/%d/merge", testGitHubOwner, testGitHubRepo, number), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		testBody(t, r, `{"commit_message":"Bump up","merge_method":"merge","sha":"abc123"}`+"\n")
+		fmt.Fprint(w, `{"merged":true}`)
+	})
+
+	mr, err := client.MergePullRequest(number, "Bump up", &github.PullRequestOptions{SHA: "abc123", MergeMethod: "merge"})
+	if err != nil {
+		t.Fatalf("GitHubClient.MergePullRequest returned unexpected error: %v", err)
+	}
+
+	want := &github.PullRequestMergeResult{Merged: github.Bool(true)}
+	if !reflect.DeepEqual(mr, want) {
+		t.Errorf("GitHubClient.MergePullRequest returned %+v, want %+v", mr, want)
+	}
+}
+
+func TestGitHubClient_EnableAutoMerge_Error(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		fmt.Fprint(w, `{"errors":[{"message":"Pull request is in clean status"}]}`)
+	})
+
+	err := client.EnableAutoMerge("MDExOlB1bGxSZXF1ZXN0MQ==", "merge", "abc123", "", "")
+	if err == nil || err.Error() != "enablePullRequestAutoMerge returns an error: Pull request is in clean status" {
+		t.Errorf("GitHubClient.EnableAutoMerge returned unexpected error: %v", err)
+	}
+}
+
+func TestGitHubClient_GetRef(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/refs/tags/v1.0.0", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"ref":"refs/tags/v1.0.0","object":{"sha":"abc123"}}`)
+	})
+
+	ref, err := client.GetRef("tags/v1.0.0")
+	if err != nil {
+		t.Fatalf("GitHubClient.GetRef returned unexpected error: %v", err)
+	}
+
+	want := &github.Reference{Ref: github.String("refs/tags/v1.0.0"), Object: &github.GitObject{SHA: github.String("abc123")}}
+	if !reflect.DeepEqual(ref, want) {
+		t.Errorf("GitHubClient.GetRef returned %+v, want %+v", ref, want)
+	}
+}
+
+func TestGitHubClient_GetRef_NotFound(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/refs/tags/v1.0.0", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+	})
+
+	ref, err := client.GetRef("tags/v1.0.0")
+	if err != nil {
+		t.Fatalf("GitHubClient.GetRef returned unexpected error: %v", err)
+	}
+
+	if ref != nil {
+		t.Errorf("GitHubClient.GetRef returned %+v, want nil", ref)
+	}
+}
+
+func TestGitHubClient_CreateRef(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/refs", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, `{"ref":"refs/tags/v1.0.0","sha":"abc123"}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"ref":"refs/tags/v1.0.0","object":{"sha":"abc123"}}`)
+	})
+
+	ref, err := client.CreateRef(&github.Reference{Ref: github.String("refs/tags/v1.0.0"), Object: &github.GitObject{SHA: github.String("abc123")}})
+	if err != nil {
+		t.Fatalf("GitHubClient.CreateRef returned unexpected error: %v", err)
+	}
+
+	want := &github.Reference{Ref: github.String("refs/tags/v1.0.0"), Object: &github.GitObject{SHA: github.String("abc123")}}
+	if !reflect.DeepEqual(ref, want) {
+		t.Errorf("GitHubClient.CreateRef returned %+v, want %+v", ref, want)
+	}
+}
+
+func TestGitHubClient_GetReleaseByTag(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/releases/tags/v1.0.0", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"tag_name":"v1.0.0"}`)
+	})
+
+	rr, err := client.GetReleaseByTag("v1.0.0")
+	if err != nil {
+		t.Fatalf("GitHubClient.GetReleaseByTag returned unexpected error: %v", err)
+	}
+
+	want := &github.RepositoryRelease{TagName: github.String("v1.0.0")}
+	if !reflect.DeepEqual(rr, want) {
+		t.Errorf("GitHubClient.GetReleaseByTag returned %+v, want %+v", rr, want)
+	}
+}
+
+func TestGitHubClient_GetReleaseByTag_NotFound(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/releases/tags/v1.0.0", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+	})
+
+	rr, err := client.GetReleaseByTag("v1.0.0")
+	if err != nil {
+		t.Fatalf("GitHubClient.GetReleaseByTag returned unexpected error: %v", err)
+	}
+
+	if rr != nil {
+		t.Errorf("GitHubClient.GetReleaseByTag returned %+v, want nil", rr)
+	}
+}
+
+func TestGitHubClient_CreateRelease(t *testing.T) {
+	cases := []struct {
+		latest bool
+		body   string
+	}{
+		{latest: true, body: `{"tag_name":"v1.0.0","name":"v1.0.0"}`},
+		{latest: false, body: `{"tag_name":"v1.0.0","name":"v1.0.0","make_latest":"false"}`},
+	}
+
+	for i, tc := range cases {
+		client, mux, _, tearDown := setup()
+
+		mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/releases", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, http.MethodPost)
+			testBody(t, r, tc.body+"\n")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"tag_name":"v1.0.0","name":"v1.0.0"}`)
+		})
+
+		rr, err := client.CreateRelease(&github.RepositoryRelease{TagName: github.String("v1.0.0"), Name: github.String("v1.0.0")}, tc.latest)
+		if err != nil {
+			t.Fatalf("#%d GitHubClient.CreateRelease returned unexpected error: %v", i, err)
+		}
+
+		want := &github.RepositoryRelease{TagName: github.String("v1.0.0"), Name: github.String("v1.0.0")}
+		if !reflect.DeepEqual(rr, want) {
+			t.Errorf("#%d GitHubClient.CreateRelease returned %+v, want %+v", i, rr, want)
+		}
+
+		tearDown()
+	}
+}
+
+func TestGitHubClient_GetRepository(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"default_branch":"master"}`)
+	})
+
+	repo, err := client.GetRepository()
+	if err != nil {
+		t.Fatalf("GitHubClient.GetRepository returned unexpected error: %v", err)
+	}
+
+	want := &github.Repository{DefaultBranch: github.String("master")}
+	if !reflect.DeepEqual(repo, want) {
+		t.Errorf("GitHubClient.GetRepository returned %+v, want %+v", repo, want)
+	}
+}
+
+func TestGitHubClient_GetCommit(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/commits/abc123", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"sha":"abc123","tree":{"sha":"tree123"}}`)
+	})
+
+	commit, err := client.GetCommit("abc123")
+	if err != nil {
+		t.Fatalf("GitHubClient.GetCommit returned unexpected error: %v", err)
+	}
+
+	want := &github.Commit{SHA: github.String("abc123"), Tree: &github.Tree{SHA: github.String("tree123")}}
+	if !reflect.DeepEqual(commit, want) {
+		t.Errorf("GitHubClient.GetCommit returned %+v, want %+v", commit, want)
+	}
+}
+
+func TestGitHubClient_CreateTree(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/trees", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, `{"base_tree":"tree123","tree":[{"path":"version.rb","mode":"100644","type":"blob","content":"VERSION"}]}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"sha":"newtree123"}`)
+	})
+
+	entries := []github.TreeEntry{{Path: github.String("version.rb"), Mode: github.String("100644"), Type: github.String("blob"), Content: github.String("VERSION")}}
+	tree, err := client.CreateTree("tree123", entries)
+	if err != nil {
+		t.Fatalf("GitHubClient.CreateTree returned unexpected error: %v", err)
+	}
+
+	want := &github.Tree{SHA: github.String("newtree123")}
+	if !reflect.DeepEqual(tree, want) {
+		t.Errorf("GitHubClient.CreateTree returned %+v, want %+v", tree, want)
+	}
+}
+
+func TestGitHubClient_CreateCommit(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/commits", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, `{"message":"Bump up","tree":"tree123","parents":["parent123"]}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"sha":"commit123"}`)
+	})
+
+	commit, err := client.CreateCommit(&github.Commit{Message: github.String("Bump up"), Tree: &github.Tree{SHA: github.String("tree123")}, Parents: []github.Commit{{SHA: github.String("parent123")}}})
+	if err != nil {
+		t.Fatalf("GitHubClient.CreateCommit returned unexpected error: %v", err)
+	}
+
+	want := &github.Commit{SHA: github.String("commit123")}
+	if !reflect.DeepEqual(commit, want) {
+		t.Errorf("GitHubClient.CreateCommit returned %+v, want %+v", commit, want)
+	}
+}
+
+func TestGitHubClient_CreatePullRequest(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/pulls", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, `{"title":"Bump up","head":"bump","base":"master"}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"number":1}`)
+	})
+
+	pr, err := client.CreatePullRequest(&github.NewPullRequest{Title: github.String("Bump up"), Head: github.String("bump"), Base: github.String("master")})
+	if err != nil {
+		t.Fatalf("GitHubClient.CreatePullRequest returned unexpected error: %v", err)
+	}
+
+	want := &github.PullRequest{Number: github.Int(1)}
+	if !reflect.DeepEqual(pr, want) {
+		t.Errorf("GitHubClient.CreatePullRequest returned %+v, want %+v", pr, want)
+	}
+}
+
+func TestGitHubClient_AddLabelsToIssue(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/issues/1/labels", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, `["bumpup"]`+"\n")
+		fmt.Fprint(w, `[{"name":"bumpup"}]`)
+	})
+
+	labels, err := client.AddLabelsToIssue(1, []string{"bumpup"})
+	if err != nil {
+		t.Fatalf("GitHubClient.AddLabelsToIssue returned unexpected error: %v", err)
+	}
+
+	want := []*github.Label{{Name: github.String("bumpup")}}
+	if !reflect.DeepEqual(labels, want) {
+		t.Errorf("GitHubClient.AddLabelsToIssue returned %+v, want %+v", labels, want)
+	}
+}
+
+func TestGitHubClient_ListReleases(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	u := fmt.Sprintf("/repos/%s/%s/releases", testGitHubOwner, testGitHubRepo)
+
+	mux.HandleFunc(u, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"tag_name":"v0.9.0"}]`)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s?page=2>; rel="next"`, u))
+		fmt.Fprint(w, `[{"tag_name":"v1.0.0","draft":true}]`)
+	})
+
+	rr, err := client.ListReleases(nil)
+	if err != nil {
+		t.Fatalf("GitHubClient.ListReleases returned unexpected error: %v", err)
+	}
+
+	want := []*github.RepositoryRelease{{TagName: github.String("v1.0.0"), Draft: github.Bool(true)}, {TagName: github.String("v0.9.0")}}
+	if !reflect.DeepEqual(rr, want) {
+		t.Errorf("GitHubClient.ListReleases returned %+v, want %+v", rr, want)
+	}
+}
+
+func TestGraphQLURL(t *testing.T) {
+	cases := []struct {
+		base     string
+		expected string
+	}{
+		{base: "https://api.github.com/", expected: "https://api.github.com/graphql"},
+		{base: "https://github.example.com/api/v3/", expected: "https://github.example.com/api/graphql"},
+	}
+
+	for i, tc := range cases {
+		base, err := url.Parse(tc.base)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := graphQLURL(base); got != tc.expected {
+			t.Errorf("#%d graphQLURL returned %s, want %s", i, got, tc.expected)
+		}
+	}
+}
+
+func TestGitHubClient_GetTag(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/git/tags/tag123", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"sha":"tag123","object":{"sha":"commit123"}}`)
+	})
+
+	tag, err := client.GetTag("tag123")
+	if err != nil {
+		t.Fatalf("GitHubClient.GetTag returned unexpected error: %v", err)
+	}
+
+	if got := tag.GetObject().GetSHA(); got != "commit123" {
+		t.Errorf("GitHubClient.GetTag returned a tag of %s, want commit123", got)
+	}
+}
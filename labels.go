@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+// reviewLabels checks if the PR has the labels required to be reviewed
+func (r *Reviewer) reviewLabels(pr *github.PullRequest) error {
+	if len(r.RequiredLabels) == 0 {
+		return nil
+	}
+
+	labels := labelNames(pr)
+
+	var matched int
+	for _, required := range r.RequiredLabels {
+		if labels[required] {
+			matched++
+		}
+	}
+
+	if r.RequireAllLabels && matched < len(r.RequiredLabels) {
+		return &skipError{Reason: SkipLabel, Message: fmt.Sprintf("Pull Request #%d does not have all of the labels: %s", pr.GetNumber(), strings.Join(r.RequiredLabels, ", "))}
+	}
+
+	if matched == 0 {
+		return &skipError{Reason: SkipLabel, Message: fmt.Sprintf("Pull Request #%d does not have any of the labels: %s", pr.GetNumber(), strings.Join(r.RequiredLabels, ", "))}
+	}
+
+	return nil
+}
+
+// bumpKinds returns the kinds of version bumps the PR's labels allow with a given config
+func bumpKinds(c *Config, pr *github.PullRequest) []string {
+	labels := labelNames(pr)
+
+	allowed := map[string]bool{}
+	for label, kind := range c.BumpLabels {
+		if labels[label] {
+			allowed[kind] = true
+		}
+	}
+
+	if len(allowed) == 0 {
+		return []string{BumpPatch}
+	}
+
+	var kinds []string
+	for kind := range allowed {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return bumpKindOrder[kinds[i]] < bumpKindOrder[kinds[j]] })
+
+	return kinds
+}
+
+func labelNames(pr *github.PullRequest) map[string]bool {
+	names := map[string]bool{}
+	for _, l := range pr.Labels {
+		names[l.GetName()] = true
+	}
+
+	return names
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func testPullRequestWithLabels(labels ...string) *github.PullRequest {
+	pr := &github.PullRequest{Number: github.Int(1)}
+	for _, l := range labels {
+		pr.Labels = append(pr.Labels, &github.Label{Name: github.String(l)})
+	}
+
+	return pr
+}
+
+func TestReviewer_ReviewLabels(t *testing.T) {
+	cases := []struct {
+		required []string
+		all      bool
+		labels   []string
+		skipped  bool
+	}{
+		{required: nil, labels: nil, skipped: false},
+		{required: []string{"bumpup"}, labels: []string{"bumpup"}, skipped: false},
+		{required: []string{"bumpup"}, labels: []string{"bug"}, skipped: true},
+		{required: []string{"bumpup", "release"}, labels: []string{"release"}, skipped: false},
+		{required: []string{"bumpup", "release"}, all: true, labels: []string{"release"}, skipped: true},
+		{required: []string{"bumpup", "release"}, all: true, labels: []string{"release", "bumpup", "bug"}, skipped: false},
+	}
+
+	for i, tc := range cases {
+		r := Reviewer{RequiredLabels: tc.required, RequireAllLabels: tc.all}
+		err := r.reviewLabels(testPullRequestWithLabels(tc.labels...))
+
+		if _, ok := err.(skip); ok != tc.skipped {
+			t.Errorf("#%d Reviewer.reviewLabels returned unexpected error: %v", i, err)
+		}
+	}
+}
+
+func TestBumpKinds(t *testing.T) {
+	config := DefaultConfig()
+	config.BumpLabels = map[string]string{"bump:minor": BumpMinor, "bump:major": BumpMajor, "bump:patch": BumpPatch}
+
+	cases := []struct {
+		labels   []string
+		expected []string
+	}{
+		{labels: nil, expected: []string{BumpPatch}},
+		{labels: []string{"bug"}, expected: []string{BumpPatch}},
+		{labels: []string{"bump:minor"}, expected: []string{BumpMinor}},
+		{labels: []string{"bump:major", "bump:patch"}, expected: []string{BumpPatch, BumpMajor}},
+	}
+
+	for i, tc := range cases {
+		if got := bumpKinds(config, testPullRequestWithLabels(tc.labels...)); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("#%d bumpKinds returned %v, want %v", i, got, tc.expected)
+		}
+	}
+}
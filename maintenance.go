@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+
+	"github.com/blang/semver"
+	"github.com/google/go-github/github"
+)
+
+// branchLineRegex finds the release line in the last segment of maintenance branch names
+// such as "1-2-stable", "release/v1.2" or "1.2.x"
+var branchLineRegex = regexp.MustCompile(`^v?(\d+)[-._](\d+)(?:[-._](?:stable|x))?$`)
+
+// versionLine is a release line such as 1.2.x
+type versionLine struct {
+	Major, Minor uint64
+}
+
+func (l versionLine) String() string {
+	return fmt.Sprintf("%d.%d", l.Major, l.Minor)
+}
+
+// contains checks if a version is of the release line
+func (l versionLine) contains(version string) bool {
+	v, err := semver.Parse(version)
+	return err == nil && v.Major == l.Major && v.Minor == l.Minor
+}
+
+// baselineRelease returns the release of a package a PR to a given base branch is compared to, and the release line of the branch.
+// PRs to maintenance branches are compared to the latest release of the branch's release line, the line is nil for other branches.
+func baselineRelease(client *GitHubClient, pkg *Package, scheme versionScheme, base *github.PullRequestBranch) (*github.RepositoryRelease, *versionLine, error) {
+	if !isMaintenanceBranch(pkg.config, base.GetRef()) {
+		latest, err := latestRelease(client, pkg, scheme, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if latest == nil {
+			return nil, nil, fmt.Errorf("there is no release whose tag matches the tag template %q", pkg.config.Tag.Template)
+		}
+
+		return latest, nil, nil
+	}
+
+	line, err := releaseLine(client, pkg, base)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	latest, err := latestRelease(client, pkg, semverScheme{}, line.contains)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if latest == nil {
+		return nil, nil, &reviewError{Check: CheckVersion, Message: fmt.Sprintf("There is no release of %s line for the maintenance branch %s, so bump-reviewer has nothing to compare the version to.", line, base.GetRef())}
+	}
+
+	return latest, &line, nil
+}
+
+func isMaintenanceBranch(c *Config, branch string) bool {
+	for _, b := range c.MaintenanceBranches {
+		if ok, _ := path.Match(b, branch); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// releaseLine returns the release line of a maintenance branch from its name,
+// or from version.rb of a package on the branch if the name does not have one
+func releaseLine(client *GitHubClient, pkg *Package, base *github.PullRequestBranch) (versionLine, error) {
+	if m := branchLineRegex.FindStringSubmatch(path.Base(base.GetRef())); m != nil {
+		major, _ := strconv.ParseUint(m[1], 10, 64)
+		minor, _ := strconv.ParseUint(m[2], 10, 64)
+		return versionLine{Major: major, Minor: minor}, nil
+	}
+
+	opt := github.RepositoryContentGetOptions{Ref: base.GetSHA()}
+	fc, _, err := client.GetContent(pkg.Path, &opt)
+	if err != nil {
+		return versionLine{}, err
+	}
+
+	content, err := decodeContent(fc)
+	if err != nil {
+		return versionLine{}, err
+	}
+
+	v, err := semver.New(pkg.parseVersion(content))
+	if err != nil {
+		return versionLine{}, &reviewError{Check: CheckVersion, Message: fmt.Sprintf("bump-reviewer could not find the release line of the maintenance branch %s from its name or %s: %s", base.GetRef(), pkg.fileName(), err)}
+	}
+
+	return versionLine{Major: v.Major, Minor: v.Minor}, nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestBaselineRelease(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+
+	// The latest release of GitHub is a backport, which the default branch is not compared to
+	setReleaseHandler(mux, "v1.2.10")
+	setVersionAtRefHandler(mux, map[string]string{"legacy123": "1.1.9", "broken123": "unknown", "calendar123": "1.2.3"})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/releases", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"tag_name":"v2.0.0"},
+			{"tag_name":"v1.2.4","draft":true},
+			{"tag_name":"v1.2.3"},
+			{"tag_name":"v1.2.10"},
+			{"tag_name":"v1.2.11-rc.1","prerelease":true},
+			{"tag_name":"v1.1.9"}
+		]`)
+	})
+
+	reviewer.Config = DefaultConfig()
+	reviewer.Config.MaintenanceBranches = []string{"*-stable", "legacy*", "release/*"}
+
+	cases := []struct {
+		ref      string
+		sha      string
+		expected string
+	}{
+		{ref: "master", expected: "v2.0.0"},
+		{ref: "1-2-stable", expected: "v1.2.10"},
+		{ref: "release/v1.2", expected: "v1.2.10"},
+		{ref: "release-2024_10-stable", sha: "calendar123", expected: "v1.2.10"},
+		{ref: "legacy", sha: "legacy123", expected: "v1.1.9"},
+		{ref: "3-0-stable"},
+		{ref: "legacy-broken", sha: "broken123"},
+	}
+
+	for i, tc := range cases {
+		release, _, err := baselineRelease(reviewer.GitHubClient, testPackage(reviewer), semverScheme{}, &github.PullRequestBranch{Ref: github.String(tc.ref), SHA: github.String(tc.sha)})
+		if tc.expected == "" {
+			if re, ok := err.(*reviewError); !ok || re.Check != CheckVersion {
+				t.Errorf("#%d baselineRelease returned %v for %s, want reviewError of %s check", i, err, tc.ref, CheckVersion)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("#%d baselineRelease returned unexpected error: %s", i, err)
+		}
+
+		if got := release.GetTagName(); got != tc.expected {
+			t.Errorf("#%d baselineRelease returned %s for %s, want %s", i, got, tc.ref, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/google/go-github/github"
+)
+
+// Methods to merge PRs
+const (
+	MergeMethodMerge  = "merge"
+	MergeMethodSquash = "squash"
+	MergeMethodRebase = "rebase"
+)
+
+// mergePullRequest merges the approved PR, or enables auto-merge on it.
+// The reviewed head SHA is passed along, so that commits pushed after the review are never merged.
+func (r *Reviewer) mergePullRequest(result *ReviewResult) error {
+	conf := configOrDefault(r.Config).Merge
+
+	title, err := render(conf.commitTitle, result)
+	if err != nil {
+		return err
+	}
+
+	message, err := render(conf.commitMessage, result)
+	if err != nil {
+		return err
+	}
+
+	if conf.AutoMerge {
+		if err := r.EnableAutoMerge(result.NodeID, conf.Method, result.HeadSHA, title, message); err != nil {
+			return fmt.Errorf("failed to enable auto-merge on Pull Request #%d: %s", result.Number, err)
+		}
+		return nil
+	}
+
+	opt := github.PullRequestOptions{CommitTitle: title, SHA: result.HeadSHA, MergeMethod: conf.Method}
+	if _, err := r.MergePullRequest(result.Number, message, &opt); err != nil {
+		return fmt.Errorf("failed to merge Pull Request #%d: %s", result.Number, err)
+	}
+
+	return nil
+}
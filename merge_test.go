@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestReviewer_MergePullRequest(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+
+	reviewer.Config = DefaultConfig()
+	reviewer.Config.Merge.Method = MergeMethodSquash
+	reviewer.Config.Merge.commitTitle, _ = parseTemplate("merge.commit_title", "Bump up to v{{.Versions.Found}} (#{{.Number}})")
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/pulls/%d/merge", testGitHubOwner, testGitHubRepo, 1), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		testBody(t, r, `{"commit_message":"","commit_title":"Bump up to v1.0.2 (#1)","merge_method":"squash","sha":"abc123"}`+"\n")
+		fmt.Fprint(w, `{"merged":true}`)
+	})
+
+	result := &ReviewResult{Number: 1, HeadSHA: "abc123", Versions: versionTable{Found: "1.0.2"}}
+	if err := reviewer.mergePullRequest(result); err != nil {
+		t.Fatalf("Reviewer.mergePullRequest returned unexpected error: %s", err)
+	}
+}
+
+func TestReviewer_MergePullRequest_HeadChanged(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/pulls/%d/merge", testGitHubOwner, testGitHubRepo, 1), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"message":"Head branch was modified. Review and try the merge again."}`)
+	})
+
+	if err := reviewer.mergePullRequest(&ReviewResult{Number: 1, HeadSHA: "abc123"}); err == nil {
+		t.Fatalf("Reviewer.mergePullRequest did not return an error")
+	}
+}
+
+func TestReviewer_MergePullRequest_AutoMerge(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+
+	reviewer.Config = DefaultConfig()
+	reviewer.Config.Merge.AutoMerge = true
+	reviewer.Config.Merge.Method = MergeMethodRebase
+
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var body struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode GraphQL request: %s", err)
+		}
+
+		want := map[string]interface{}{"id": "MDExOlB1bGxSZXF1ZXN0MQ==", "method": "REBASE", "sha": "abc123"}
+		if !reflect.DeepEqual(body.Variables, want) {
+			t.Errorf("GraphQL variables: %v, want %v", body.Variables, want)
+		}
+
+		fmt.Fprint(w, `{"data":{"enablePullRequestAutoMerge":{"clientMutationId":null}}}`)
+	})
+
+	result := &ReviewResult{Number: 1, HeadSHA: "abc123", NodeID: "MDExOlB1bGxSZXF1ZXN0MQ=="}
+	if err := reviewer.mergePullRequest(result); err != nil {
+		t.Fatalf("Reviewer.mergePullRequest returned unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+const CheckMergedLabels = "merged PR labels"
+
+// reviewMergedLabels checks if the PR bumps version enough for the labels of the PRs merged since the latest release
+func (r *Reviewer) reviewMergedLabels(pkg *Package, commits []github.RepositoryCommit, result *ReviewResult) error {
+	prs, err := mergedPullRequests(r.GitHubClient, commits)
+	if err != nil {
+		return err
+	}
+
+	required := RequiredBumpResult{Kind: BumpPatch}
+
+	for _, pr := range prs {
+		kind, labels := changeKind(pkg.config, pr)
+		if len(labels) == 0 {
+			continue
+		}
+
+		merged := MergedPullRequest{Number: pr.GetNumber(), Title: pr.GetTitle(), Labels: labels}
+		switch {
+		case bumpKindOrder[kind] > bumpKindOrder[required.Kind]:
+			required = RequiredBumpResult{Kind: kind, PullRequests: []MergedPullRequest{merged}}
+		case kind == required.Kind:
+			required.PullRequests = append(required.PullRequests, merged)
+		}
+	}
+	result.RequiredBump = required
+
+	actual := bumpKindOf(r.versionScheme(pkg), result.Versions.Baseline, result.Versions.Found)
+	if bumpKindOrder[actual] >= bumpKindOrder[required.Kind] {
+		return nil
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "Pull Request #%d bumps %s version, but the Pull Requests merged since the latest release %s need a %s version bump.\n", result.Number, actual, result.Release.Tag, required.Kind)
+	for _, pr := range required.PullRequests {
+		fmt.Fprintf(&b, "\n- #%d %s (%s)", pr.Number, pr.Title, strings.Join(pr.Labels, ", "))
+	}
+
+	return &reviewError{Check: CheckMergedLabels, Message: b.String()}
+}
+
+// changeKind returns the kind of version bump a merged PR needs and the labels which need it
+func changeKind(c *Config, pr *github.PullRequest) (string, []string) {
+	kind := BumpPatch
+	var labels []string
+	for name := range labelNames(pr) {
+		k, ok := c.ChangeLabels[name]
+		if !ok {
+			continue
+		}
+
+		switch {
+		case bumpKindOrder[k] > bumpKindOrder[kind]:
+			kind = k
+			labels = []string{name}
+		case k == kind:
+			labels = append(labels, name)
+		}
+	}
+	sort.Strings(labels)
+
+	return kind, labels
+}
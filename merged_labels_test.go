@@ -0,0 +1,61 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestReviewer_ReviewMergedLabels(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+
+	setPullRequestHandler(mux, 11, `{"number":11,"title":"Fix a bug","labels":[{"name":"bugfix"}]}`)
+	setPullRequestHandler(mux, 12, `{"number":12,"title":"Add a feature","labels":[{"name":"feature"},{"name":"bugfix"}]}`)
+	setPullRequestHandler(mux, 13, `{"number":13,"title":"Update README","labels":[{"name":"docs"}]}`)
+	setPullRequestHandler(mux, 14, `{"number":14,"title":"Add another feature","labels":[{"name":"feature"}]}`)
+
+	reviewer.Config = DefaultConfig()
+	reviewer.Config.ChangeLabels = map[string]string{"bugfix": BumpPatch, "feature": BumpMinor, "breaking": BumpMajor}
+
+	var commits []github.RepositoryCommit
+	for _, m := range []string{"Fix a bug (#11)", "Merge pull request #12 from shuheiktgw/feature", "Update README (#13)", "Add another feature (#14)", "Close an issue (#99)", "Fix a typo"} {
+		commits = append(commits, github.RepositoryCommit{Commit: &github.Commit{Message: github.String(m)}})
+	}
+
+	cases := []struct {
+		found    string
+		rejected bool
+	}{
+		{found: "1.0.2", rejected: true},
+		{found: "1.1.0"},
+		{found: "2.0.0"},
+	}
+
+	for i, tc := range cases {
+		result := &ReviewResult{Number: 1, Release: ReleaseResult{Tag: "v1.0.1"}, Versions: versionTable{Baseline: "1.0.1", Found: tc.found}}
+
+		err := reviewer.reviewMergedLabels(testPackage(reviewer), commits, result)
+		if tc.rejected {
+			want := "Pull Request #1 bumps patch version, but the Pull Requests merged since the latest release v1.0.1 need a minor version bump.\n\n" +
+				"- #12 Add a feature (feature)\n- #14 Add another feature (feature)"
+			if re, ok := err.(*reviewError); !ok || re.Check != CheckMergedLabels || re.Message != want {
+				t.Errorf("#%d Reviewer.reviewMergedLabels returned %v, want reviewError: %s", i, err, want)
+			}
+		} else if err != nil {
+			t.Errorf("#%d Reviewer.reviewMergedLabels returned unexpected error: %s", i, err)
+		}
+
+		want := RequiredBumpResult{
+			Kind: BumpMinor,
+			PullRequests: []MergedPullRequest{
+				{Number: 12, Title: "Add a feature", Labels: []string{"feature"}},
+				{Number: 14, Title: "Add another feature", Labels: []string{"feature"}},
+			},
+		}
+		if !reflect.DeepEqual(result.RequiredBump, want) {
+			t.Errorf("#%d Reviewer.reviewMergedLabels set %+v, want %+v", i, result.RequiredBump, want)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// otherChangesTitle is the title of the section listing PRs which match no configured section
+const otherChangesTitle = "Other changes"
+
+var (
+	mergeCommitRegex  = regexp.MustCompile(`^Merge pull request #(\d+) from `)
+	squashCommitRegex = regexp.MustCompile(`\(#(\d+)\)$`)
+)
+
+// Notes returns the release notes of a bump up PR without creating anything.
+// The notes of each package are headed by its name when the PR bumps packages.
+func (r *Releaser) Notes(number int) (string, error) {
+	pr, err := r.GetPullRequest(number)
+	if err != nil {
+		return "", err
+	}
+
+	head := pr.GetHead().GetSHA()
+	if pr.GetMerged() {
+		head = pr.GetMergeCommitSHA()
+	}
+
+	pkgs, err := r.bumpedPackages(number)
+	if err != nil {
+		return "", err
+	}
+
+	var b bytes.Buffer
+	for i, pkg := range pkgs {
+		notes, err := r.releaseNotes(pr, pkg, head)
+		if err != nil {
+			return "", err
+		}
+
+		if len(configOrDefault(r.Config).Packages) == 0 {
+			return notes, nil
+		}
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "# %s\n\n%s", pkg.Name, notes)
+	}
+
+	return b.String(), nil
+}
+
+// releaseNotes lists the PRs merged between the release of a package the PR was compared to and head, grouped by their labels
+func (r *Releaser) releaseNotes(pr *github.PullRequest, pkg *Package, head string) (string, error) {
+	release, _, err := baselineRelease(r.GitHubClient, pkg, newVersionScheme(pkg.config, time.Now), pr.GetBase())
+	if err != nil {
+		return "", err
+	}
+	base := release.GetTagName()
+
+	cc, err := r.CompareCommits(base, head)
+	if err != nil {
+		return "", err
+	}
+
+	// Only the PRs which change the package are its changes
+	commits, err := packageCommits(r.GitHubClient, pkg, cc, head)
+	if err != nil {
+		return "", err
+	}
+
+	merged, err := mergedPullRequests(r.GitHubClient, commits)
+	if err != nil {
+		return "", err
+	}
+
+	var prs []*github.PullRequest
+	for _, m := range merged {
+		// The bump up PR itself is not a change to note
+		if m.GetNumber() != pr.GetNumber() {
+			prs = append(prs, m)
+		}
+	}
+
+	if len(prs) == 0 {
+		return fmt.Sprintf("There are no Pull Requests merged since %s.\n", base), nil
+	}
+
+	return formatNotes(prs, pkg.config.ReleaseNotes.Sections), nil
+}
+
+// pullRequestNumbers finds the PRs commits come from by their merge or squash commit messages
+func pullRequestNumbers(commits []github.RepositoryCommit) []int {
+	var numbers []int
+	seen := map[int]bool{}
+	for _, c := range commits {
+		title := strings.SplitN(c.GetCommit().GetMessage(), "\n", 2)[0]
+
+		m := mergeCommitRegex.FindStringSubmatch(title)
+		if m == nil {
+			m = squashCommitRegex.FindStringSubmatch(title)
+		}
+		if m == nil {
+			continue
+		}
+
+		n, err := strconv.Atoi(m[1])
+		if err != nil || seen[n] {
+			continue
+		}
+		seen[n] = true
+		numbers = append(numbers, n)
+	}
+
+	return numbers
+}
+
+// mergedPullRequests fetches the PRs commits come from, skipping numbers which are not PRs of the repository
+func mergedPullRequests(client *GitHubClient, commits []github.RepositoryCommit) ([]*github.PullRequest, error) {
+	var prs []*github.PullRequest
+	for _, n := range pullRequestNumbers(commits) {
+		pr, err := client.GetPullRequest(n)
+		// The number in a commit message may be an issue or a PR of another repository
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, pr)
+	}
+
+	return prs, nil
+}
+
+// formatNotes renders PRs as markdown, each PR is listed in the first section which has any of its labels
+func formatNotes(prs []*github.PullRequest, sections []NotesSection) string {
+	grouped := make([][]*github.PullRequest, len(sections)+1)
+	for _, pr := range prs {
+		i := len(sections)
+		for j, section := range sections {
+			if hasAnyLabel(pr, section.Labels) {
+				i = j
+				break
+			}
+		}
+		grouped[i] = append(grouped[i], pr)
+	}
+
+	titles := make([]string, 0, len(sections)+1)
+	for _, section := range sections {
+		titles = append(titles, section.Title)
+	}
+	if len(sections) == 0 {
+		titles = append(titles, "Changes")
+	} else {
+		titles = append(titles, otherChangesTitle)
+	}
+
+	var b bytes.Buffer
+	for i, group := range grouped {
+		if len(group) == 0 {
+			continue
+		}
+
+		if b.Len() != 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "## %s\n\n", titles[i])
+		for _, pr := range group {
+			fmt.Fprintf(&b, "- %s (#%d) by @%s\n", pr.GetTitle(), pr.GetNumber(), pr.GetUser().GetLogin())
+		}
+	}
+
+	return b.String()
+}
+
+func hasAnyLabel(pr *github.PullRequest, labels []string) bool {
+	names := labelNames(pr)
+	for _, l := range labels {
+		if names[l] {
+			return true
+		}
+	}
+
+	return false
+}
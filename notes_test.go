@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestPullRequestNumbers(t *testing.T) {
+	messages := []string{
+		"Merge pull request #12 from shuheiktgw/feature\n\nAdd a feature",
+		"Fix a bug (#13)",
+		"Fix a typo directly on master",
+		"Merge pull request #12 from shuheiktgw/feature",
+		"Refactor (#14)\n\n* Refactor (#10)",
+	}
+
+	var commits []github.RepositoryCommit
+	for _, m := range messages {
+		commits = append(commits, github.RepositoryCommit{Commit: &github.Commit{Message: github.String(m)}})
+	}
+
+	want := []int{12, 13, 14}
+	if got := pullRequestNumbers(commits); !reflect.DeepEqual(got, want) {
+		t.Errorf("pullRequestNumbers returned %v, want %v", got, want)
+	}
+}
+
+func TestFormatNotes(t *testing.T) {
+	pr := func(number int, title string, labels ...string) *github.PullRequest {
+		p := &github.PullRequest{Number: github.Int(number), Title: github.String(title), User: &github.User{Login: github.String("shuheiktgw")}}
+		for _, l := range labels {
+			p.Labels = append(p.Labels, &github.Label{Name: github.String(l)})
+		}
+		return p
+	}
+	prs := []*github.PullRequest{pr(12, "Add a feature", "feature"), pr(13, "Fix a bug", "bug", "feature"), pr(14, "Refactor")}
+
+	cases := []struct {
+		sections []NotesSection
+		want     string
+	}{
+		{
+			want: "## Changes\n\n- Add a feature (#12) by @shuheiktgw\n- Fix a bug (#13) by @shuheiktgw\n- Refactor (#14) by @shuheiktgw\n",
+		},
+		{
+			sections: []NotesSection{{Title: "Bug fixes", Labels: []string{"bug"}}, {Title: "Features", Labels: []string{"feature", "enhancement"}}, {Title: "Docs", Labels: []string{"docs"}}},
+			want:     "## Bug fixes\n\n- Fix a bug (#13) by @shuheiktgw\n\n## Features\n\n- Add a feature (#12) by @shuheiktgw\n\n## Other changes\n\n- Refactor (#14) by @shuheiktgw\n",
+		},
+	}
+
+	for i, tc := range cases {
+		if got := formatNotes(prs, tc.sections); got != tc.want {
+			t.Errorf("#%d formatNotes returned unexpected notes: want: %s, got: %s", i, tc.want, got)
+		}
+	}
+}
+
+func TestReleaser_Notes(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	setPullRequestHandler(mux, 3, `{"number":3,"merged":false,"head":{"sha":"head123"}}`)
+	setPullRequestHandler(mux, 2, `{"number":2,"title":"Add a feature","user":{"login":"shuheiktgw"},"labels":[{"name":"feature"}]}`)
+	setReleaseHandler(mux, "v1.0.0")
+	setCompareHandler(mux, "v1.0.0", "head123", `{"commits":[{"commit":{"message":"Merge pull request #2 from shuheiktgw/feature"}},{"commit":{"message":"Fix a crash reported in shuheiktgw/other (#99)"}},{"commit":{"message":"Bump up to v1.1.0 (#3)"}}]}`)
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/releases", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Releaser.Notes created a release")
+	})
+
+	conf := DefaultConfig()
+	conf.ReleaseNotes.Sections = []NotesSection{{Title: "Features", Labels: []string{"feature"}}}
+	releaser := Releaser{GitHubClient: client, Config: conf}
+
+	got, err := releaser.Notes(3)
+	if err != nil {
+		t.Fatalf("Releaser.Notes returned unexpected error: %s", err)
+	}
+
+	want := "## Features\n\n- Add a feature (#2) by @shuheiktgw\n"
+	if got != want {
+		t.Errorf("Releaser.Notes returned unexpected notes: want: %s, got: %s", want, got)
+	}
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+)
+
+// What bump-reviewer does when a PR bumps more than one package
+const (
+	MultiplePackagesReject = "reject"
+	MultiplePackagesReview = "review"
+)
+
+// defaultPackageTagTemplate is the tag template of packages when neither the package nor the repository has one
+const defaultPackageTagTemplate = "{{.Package}}-v{{.Version}}"
+
+// PackageConfig is a gem of a repository which hosts several gems.
+// Tag, Versioning and BumpLabels override the ones of the repository for the gem.
+type PackageConfig struct {
+	// Name is the name of the gem, and it is required
+	Name string `json:"name"`
+
+	// Path is the path of version.rb, or of the version file Pattern finds the version in.
+	// It is "gems/<name>/lib/<name>/version.rb" by default.
+	Path string `json:"path"`
+
+	// Namespace is the module which defines VERSION, which is the camel cased name by default
+	Namespace string `json:"namespace"`
+
+	// Dir is the directory of the gem. Only changes in it are changes of the gem.
+	// It is the part of Path before "/lib/" by default, or the directory of Path if there is no such part.
+	Dir string `json:"dir"`
+
+	// Pattern finds the version in the file at Path like version_file.pattern, which it overrides
+	Pattern string `json:"pattern"`
+
+	// Tag is "{{.Package}}-v{{.Version}}" by default unless the repository has a tag template
+	Tag *TagConfig `json:"tag"`
+
+	Versioning *VersioningConfig `json:"versioning"`
+
+	BumpLabels map[string]string `json:"bump_labels"`
+
+	config *Config
+}
+
+// init fills the defaults of the package and derives its config from the config of the repository
+func (p *PackageConfig) init(root *Config) error {
+	if p.Name == "" {
+		return errors.New("name is required")
+	}
+	if p.Path == "" {
+		p.Path = fmt.Sprintf("gems/%s/lib/%s/version.rb", p.Name, p.Name)
+	}
+	if p.Namespace == "" {
+		p.Namespace = strcase.ToCamel(p.Name)
+	}
+	if p.Dir == "" {
+		if i := strings.Index(p.Path, "/lib/"); i >= 0 {
+			p.Dir = p.Path[:i]
+		} else {
+			p.Dir = path.Dir(p.Path)
+		}
+	}
+	// A gem at the root of the repository owns every file
+	if p.Dir = path.Clean(p.Dir); p.Dir == "." {
+		p.Dir = ""
+	}
+
+	c := root.clone()
+	c.Packages = nil
+	c.Registry.Gem = p.Name
+	c.VersionFile.Path = p.Path
+	if p.Pattern != "" {
+		c.VersionFile.Pattern = p.Pattern
+	}
+	if p.Tag != nil {
+		c.Tag = *p.Tag
+	} else if c.Tag.Template == "" {
+		c.Tag = TagConfig{Template: defaultPackageTagTemplate}
+	}
+	if p.Versioning != nil {
+		c.Versioning = *p.Versioning
+	}
+	if p.BumpLabels != nil {
+		c.BumpLabels = p.BumpLabels
+	}
+
+	if err := c.init(); err != nil {
+		return err
+	}
+	p.config = &c
+
+	return nil
+}
+
+// Package is a gem in a repository along with the config applied to it
+type Package struct {
+	Name      string
+	Path      string
+	Namespace string
+
+	// Dir is the directory of the gem, which is empty when the repository is the gem
+	Dir string
+
+	repo   string
+	config *Config
+}
+
+// packages returns the gems of a repository. Without packages configured, the repository is a single gem
+// whose version file is at version_file.path, or at lib/<repo>/version.rb by default.
+func (c *Config) packages(repo string) []*Package {
+	if len(c.Packages) == 0 {
+		p := c.VersionFile.Path
+		if p == "" {
+			p = versionFilePath(repo)
+		}
+		return []*Package{{Name: repo, Path: p, Namespace: strcase.ToCamel(repo), repo: repo, config: c}}
+	}
+
+	pkgs := make([]*Package, 0, len(c.Packages))
+	for _, p := range c.Packages {
+		pkgs = append(pkgs, &Package{Name: p.Name, Path: p.Path, Namespace: p.Namespace, Dir: p.Dir, repo: repo, config: p.config})
+	}
+
+	return pkgs
+}
+
+// findPackage returns the package of a given name, or nil if there is no such package
+func (c *Config) findPackage(repo, name string) *Package {
+	for _, p := range c.packages(repo) {
+		if p.Name == name {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// packageAt returns the package whose version.rb is at a given path, or nil if there is no such package
+func (c *Config) packageAt(repo, filename string) *Package {
+	for _, p := range c.packages(repo) {
+		if p.Path == filename {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// extractVersion finds the version in the content of the version file of the package
+func (p *Package) extractVersion(content string) (versionMatch, bool) {
+	pattern := p.config.VersionFile.pattern
+	if pattern == nil {
+		pattern = rubyVersionRegex
+	}
+
+	return extractVersion(pattern, content)
+}
+
+// parseVersion returns the version in the content of the version file of the package.
+// It returns an empty string if the version is not found.
+func (p *Package) parseVersion(content string) string {
+	m, _ := p.extractVersion(content)
+	return m.Version
+}
+
+// owns checks if a file of the repository belongs to the package
+func (p *Package) owns(filename string) bool {
+	return p.Dir == "" || strings.HasPrefix(filename, p.Dir+"/")
+}
+
+// fileName is the name messages call the version file of the package
+func (p *Package) fileName() string {
+	return path.Base(p.Path)
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func testPackagesConfig(t *testing.T, multiple string) *Config {
+	c := &Config{
+		Packages: []PackageConfig{
+			{Name: "gem-a"},
+			{Name: "gem-b", Path: "gems/b/lib/gem_b/version.rb", Namespace: "GemB", Tag: &TagConfig{Template: "b/v{{.Version}}"}},
+		},
+		MultiplePackages: multiple,
+	}
+	if err := c.init(); err != nil {
+		t.Fatalf("Config.init returned unexpected error: %s", err)
+	}
+
+	return c
+}
+
+// setPackagesHandlers serves the releases of the packages and version.rb of gem-a and gem-b on the PR's head
+func setPackagesHandlers(mux *http.ServeMux, versionA, versionB string) {
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/releases", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"tag_name":"gem-a-v1.0.0"},{"tag_name":"b/v2.0.0"},{"tag_name":"v9.0.0"}]`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/refs/tags/", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+	})
+
+	setContentHandler(mux, "gems/gem-a/lib/gem-a/version.rb", fmt.Sprintf("module GemA\n  VERSION = \"%s\"\nend\n", versionA))
+	setContentHandler(mux, "gems/b/lib/gem_b/version.rb", fmt.Sprintf("module GemB\n  VERSION = \"%s\"\nend\n", versionB))
+
+	setChangesHandler(mux)
+	setPullRequestsHandler(mux, "[]")
+}
+
+func TestConfig_Packages(t *testing.T) {
+	pkgs := testPackagesConfig(t, "").packages("monorepo")
+	if len(pkgs) != 2 {
+		t.Fatalf("Config.packages returned %d packages, want 2", len(pkgs))
+	}
+
+	cases := []struct {
+		pkg       *Package
+		path      string
+		namespace string
+		tag       string
+	}{
+		{pkg: pkgs[0], path: "gems/gem-a/lib/gem-a/version.rb", namespace: "GemA", tag: "gem-a-v1.2.3"},
+		{pkg: pkgs[1], path: "gems/b/lib/gem_b/version.rb", namespace: "GemB", tag: "b/v1.2.3"},
+		{pkg: DefaultConfig().packages("bump-reviewer")[0], path: "lib/bump-reviewer/version.rb", namespace: "BumpReviewer", tag: "v1.2.3"},
+	}
+
+	for i, tc := range cases {
+		if tc.pkg.Path != tc.path || tc.pkg.Namespace != tc.namespace {
+			t.Errorf("#%d Config.packages returned a package at %s in %s, want %s in %s", i, tc.pkg.Path, tc.pkg.Namespace, tc.path, tc.namespace)
+		}
+
+		tag, err := tc.pkg.formatTag("1.2.3")
+		if err != nil {
+			t.Fatalf("#%d Package.formatTag returned unexpected error: %s", i, err)
+		}
+		if tag != tc.tag {
+			t.Errorf("#%d Package.formatTag returned %s, want %s", i, tag, tc.tag)
+		}
+	}
+}
+
+func TestConfig_PackageAt(t *testing.T) {
+	c := testPackagesConfig(t, "")
+
+	if pkg := c.packageAt("monorepo", "gems/b/lib/gem_b/version.rb"); pkg == nil || pkg.Name != "gem-b" {
+		t.Errorf("Config.packageAt returned %v, want gem-b", pkg)
+	}
+
+	if pkg := c.packageAt("monorepo", "lib/monorepo/version.rb"); pkg != nil {
+		t.Errorf("Config.packageAt returned %s, want nil", pkg.Name)
+	}
+}
+
+func TestReviewer_Review_Package(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+	reviewer.Config = testPackagesConfig(t, "")
+
+	number := 1
+	setPullRequestHandler(mux, number, `{"number":1}`)
+	setPullRequestFilesHandler(mux, number, `[{"filename":"gems/gem-a/lib/gem-a/version.rb"}]`)
+	setPackagesHandlers(mux, "1.0.1", "2.0.0")
+
+	var body string
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/pulls/%d/reviews", testGitHubOwner, testGitHubRepo, number), func(w http.ResponseWriter, r *http.Request) {
+		body = readBody(t, r)
+		fmt.Fprint(w, `{"state":"APPROVED"}`)
+	})
+
+	if err := reviewer.Review(number); err != nil {
+		t.Fatalf("Reviewer.Review returned unexpected error: %s", err)
+	}
+
+	if !strings.Contains(body, "`gem-a`: PR increments patch version by one") {
+		t.Errorf("Reviewer.Review approved with unexpected body: %s", body)
+	}
+}
+
+func TestReviewer_Review_PackageBumpLabels(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+	reviewer.Config = testPackagesConfig(t, "")
+	reviewer.Config.Packages[1].config.BumpLabels = map[string]string{"gem-b:minor": BumpMinor}
+
+	number := 1
+	setPullRequestHandler(mux, number, `{"number":1,"labels":[{"name":"gem-b:minor"}]}`)
+	setPullRequestFilesHandler(mux, number, `[{"filename":"gems/b/lib/gem_b/version.rb"}]`)
+	setCreateReviewHandler(mux, number, "APPROVED")
+	setPackagesHandlers(mux, "1.0.1", "2.1.0")
+
+	if err := reviewer.Review(number); err != nil {
+		t.Fatalf("Reviewer.Review returned unexpected error: %s", err)
+	}
+}
+
+func TestReviewer_Review_PackageBaseline(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+	reviewer.Config = testPackagesConfig(t, "")
+
+	number := 1
+	setPullRequestHandler(mux, number, `{"number":1}`)
+	setPullRequestFilesHandler(mux, number, `[{"filename":"gems/b/lib/gem_b/version.rb"}]`)
+	setCreateReviewHandler(mux, number, "COMMENT")
+	setPackagesHandlers(mux, "1.0.1", "1.0.1")
+
+	err := reviewer.Review(number)
+	r, ok := err.(review)
+	if !ok {
+		t.Fatalf("Reviewer.Review returned unexpected error: %s", err)
+	}
+
+	if !strings.Contains(r.review(), "version.rb downgrades the version from 2.0.0 to 1.0.1") {
+		t.Errorf("Reviewer.Review returned unexpected error: %s", err)
+	}
+}
+
+func TestReviewer_Review_MultiplePackages(t *testing.T) {
+	cases := []struct {
+		multiple string
+		expected string
+	}{
+		{multiple: "", expected: "bumps more than one package: gem-a, gem-b"},
+		{multiple: MultiplePackagesReview, expected: ""},
+	}
+
+	for i, tc := range cases {
+		reviewer, mux, _, tearDown := setupReviewer()
+		reviewer.Config = testPackagesConfig(t, tc.multiple)
+
+		number := 1
+		setPullRequestHandler(mux, number, `{"number":1}`)
+		setPullRequestFilesHandler(mux, number, `[{"filename":"gems/gem-a/lib/gem-a/version.rb"},{"filename":"gems/b/lib/gem_b/version.rb"}]`)
+		setCreateReviewHandler(mux, number, "COMMENT")
+		setPackagesHandlers(mux, "1.0.1", "2.0.1")
+
+		err := reviewer.Review(number)
+		tearDown()
+
+		if tc.expected == "" {
+			if err != nil {
+				t.Errorf("#%d Reviewer.Review returned unexpected error: %s", i, err)
+			}
+			continue
+		}
+
+		if r, ok := err.(review); !ok || !strings.Contains(r.review(), tc.expected) {
+			t.Errorf("#%d Reviewer.Review returned unexpected error: %v", i, err)
+		}
+	}
+}
+
+func TestReviewer_Review_PackageUnexpectedFile(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+	reviewer.Config = testPackagesConfig(t, MultiplePackagesReview)
+
+	number := 1
+	setPullRequestHandler(mux, number, `{"number":1}`)
+	setPullRequestFilesHandler(mux, number, `[{"filename":"gems/gem-a/lib/gem-a/version.rb"},{"filename":"gems/gem-a/lib/gem-a/cli.rb"}]`)
+	setCreateReviewHandler(mux, number, "COMMENT")
+
+	err := reviewer.Review(number)
+	r, ok := err.(review)
+	if !ok {
+		t.Fatalf("Reviewer.Review returned unexpected error: %s", err)
+	}
+
+	if !strings.Contains(r.review(), "edited unexpected file gems/gem-a/lib/gem-a/cli.rb") {
+		t.Errorf("Reviewer.Review returned unexpected error: %s", err)
+	}
+}
+
+func TestProposer_TargetPackage(t *testing.T) {
+	conf := testPackagesConfig(t, "")
+
+	cases := []struct {
+		name     string
+		expected string
+	}{
+		{name: "gem-b", expected: "gem-b"},
+		{name: ""},
+		{name: "gem-c"},
+	}
+
+	for i, tc := range cases {
+		proposer := Proposer{GitHubClient: &GitHubClient{Repo: "monorepo"}, Config: conf, Package: tc.name}
+		pkg, err := proposer.targetPackage()
+		if tc.expected == "" {
+			if err == nil {
+				t.Errorf("#%d Proposer.targetPackage did not return an error for package %q", i, tc.name)
+			}
+			continue
+		}
+
+		if err != nil || pkg.Name != tc.expected {
+			t.Errorf("#%d Proposer.targetPackage returned %v, %v, want %s", i, pkg, err, tc.expected)
+		}
+	}
+}
+
+func TestConfig_MultiplePackagesTemplates(t *testing.T) {
+	cases := []Config{
+		// Each package in .Packages has its own release and versions
+		{MultiplePackages: MultiplePackagesReview, Merge: MergeConfig{CommitTitle: "Bump up {{range .Packages}}{{.Name}} to v{{.Versions.Found}} {{end}}(#{{.Number}})"}},
+		{MultiplePackages: MultiplePackagesReview, Merge: MergeConfig{CommitTitle: "{{range $p := .Packages}}{{$p.Release.Tag}} {{end}}"}},
+		// The result describes the one package a PR bumps with multiple_packages reject
+		{MultiplePackages: MultiplePackagesReject, Merge: MergeConfig{CommitTitle: "Bump up to v{{.Versions.Found}}"}},
+	}
+
+	for i, c := range cases {
+		c.Packages = []PackageConfig{{Name: "gem-a"}, {Name: "gem-b"}}
+		if err := c.init(); err != nil {
+			t.Errorf("#%d Config.init returned unexpected error: %s", i, err)
+		}
+	}
+}
+
+func TestPackageConfig_Init_Copy(t *testing.T) {
+	c := &Config{
+		ChangeLabels:        map[string]string{"feature": BumpMinor},
+		MaintenanceBranches: []string{"*-stable"},
+		ReleaseNotes:        ReleaseNotesConfig{Sections: []NotesSection{{Title: "Features", Labels: []string{"feature"}}}},
+		Packages:            []PackageConfig{{Name: "gem-a"}},
+	}
+	if err := c.init(); err != nil {
+		t.Fatalf("Config.init returned unexpected error: %s", err)
+	}
+
+	// Changes to the config of a package must not leak into the repository's
+	pc := c.Packages[0].config
+	pc.ChangeLabels["breaking"] = BumpMajor
+	pc.MaintenanceBranches[0] = "release/*"
+	pc.ReleaseNotes.Sections[0].Labels[0] = "enhancement"
+
+	if len(c.ChangeLabels) != 1 || c.MaintenanceBranches[0] != "*-stable" || c.ReleaseNotes.Sections[0].Labels[0] != "feature" {
+		t.Errorf("PackageConfig.init shared maps or slices with the config of the repository: %+v", c)
+	}
+}
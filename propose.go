@@ -0,0 +1,247 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/github"
+)
+
+// Proposer opens bump up PRs which pass the review of bump-reviewer
+type Proposer struct {
+	*GitHubClient
+
+	// Config is the configuration the proposed PR is reviewed with
+	Config *Config
+
+	// Labels are added to the PR along with the label of the bump kind
+	Labels []string
+
+	// Package is the name of the package to bump, which is required when packages are configured
+	Package string
+
+	// Reviewer is the login of the user bump-reviewer reviews as. If the token belongs to them,
+	// Propose fails before opening anything since they could not approve their own PR.
+	Reviewer string
+}
+
+// Propose bumps the version of the latest release by a given kind on a new branch
+// and opens a PR of it against the default branch
+func (p *Proposer) Propose(kind string) (*github.PullRequest, error) {
+	if !validBumpKind(kind) {
+		return nil, fmt.Errorf("unknown bump kind %q, it must be one of patch, minor or major", kind)
+	}
+
+	pkg, err := p.targetPackage()
+	if err != nil {
+		return nil, err
+	}
+
+	label, err := bumpLabel(pkg.config, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.checkToken(); err != nil {
+		return nil, err
+	}
+
+	repo, err := p.GetRepository()
+	if err != nil {
+		return nil, err
+	}
+	base := repo.GetDefaultBranch()
+
+	scheme := newVersionScheme(pkg.config, time.Now)
+	release, line, err := baselineRelease(p.GitHubClient, pkg, scheme, &github.PullRequestBranch{Ref: github.String(base)})
+	if err != nil {
+		return nil, err
+	}
+
+	baseline, err := pkg.parseTag(release.GetTagName())
+	if err != nil {
+		return nil, err
+	}
+
+	// Bump the same baseline the review compares the version to
+	if pkg.config.Registry.enabled() && pkg.config.Registry.Baseline {
+		if baseline, err = registryBaseline(pkg, scheme, baseline, line); err != nil {
+			return nil, err
+		}
+	}
+
+	next, err := scheme.next(baseline, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := pkg.formatTag(next)
+	if err != nil {
+		return nil, err
+	}
+
+	branch := "bump-reviewer/" + tag
+	title := fmt.Sprintf("Bump up to %s", tag)
+	existing, err := p.GetRef("heads/" + branch)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("branch %s already exists, a Pull Request to bump up to %s might have been opened already", branch, tag)
+	}
+
+	ref, err := p.GetRef("heads/" + base)
+	if err != nil {
+		return nil, err
+	}
+	if ref == nil {
+		return nil, fmt.Errorf("branch %s is not found", base)
+	}
+	parent := ref.GetObject().GetSHA()
+
+	sha, err := p.commitVersion(pkg, parent, next, title)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.CreateRef(&github.Reference{Ref: github.String("refs/heads/" + branch), Object: &github.GitObject{SHA: github.String(sha)}}); err != nil {
+		return nil, err
+	}
+
+	body := fmt.Sprintf("bump-reviewer bumps %s version from %s to %s.", kind, baseline, next)
+	pr, err := p.CreatePullRequest(&github.NewPullRequest{
+		Title: github.String(title),
+		Head:  github.String(branch),
+		Base:  github.String(base),
+		Body:  github.String(body),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	labels := append([]string{}, p.Labels...)
+	if label != "" {
+		labels = append(labels, label)
+	}
+
+	if len(labels) != 0 {
+		if _, err := p.AddLabelsToIssue(pr.GetNumber(), labels); err != nil {
+			return nil, err
+		}
+	}
+
+	return pr, nil
+}
+
+// targetPackage returns the package to bump, which is the repository itself without packages configured
+func (p *Proposer) targetPackage() (*Package, error) {
+	if len(configOrDefault(p.Config).Packages) == 0 {
+		return configOrDefault(p.Config).packages(p.Repo)[0], nil
+	}
+
+	if p.Package == "" {
+		return nil, fmt.Errorf("the repository has several packages, please specify one of them to bump")
+	}
+
+	pkg := configOrDefault(p.Config).findPackage(p.Repo, p.Package)
+	if pkg == nil {
+		return nil, fmt.Errorf("package %q is not found in the config", p.Package)
+	}
+
+	return pkg, nil
+}
+
+// checkToken fails if the token belongs to the reviewer, who would open the PR and could not approve it
+func (p *Proposer) checkToken() error {
+	if p.Reviewer == "" {
+		return nil
+	}
+
+	user, _, err := p.GetAuthenticatedUser()
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(user.GetLogin(), p.Reviewer) {
+		return fmt.Errorf("GitHub Personal Access Token belongs to @%s, who reviews the Pull Request. "+
+			"GitHub does not allow to approve your own Pull Request, please propose with a token of another user", user.GetLogin())
+	}
+
+	return nil
+}
+
+// bumpLabel returns a label which allows a given kind of version bump.
+// It returns an empty string for patch bumps if no label is configured for them.
+func bumpLabel(c *Config, kind string) (string, error) {
+	var labels []string
+	for label, k := range c.BumpLabels {
+		if k == kind {
+			labels = append(labels, label)
+		}
+	}
+
+	if len(labels) == 0 {
+		if kind == BumpPatch {
+			return "", nil
+		}
+		return "", fmt.Errorf("bump_labels has no label for %s version bumps, so bump-reviewer would not approve the Pull Request", kind)
+	}
+
+	sort.Strings(labels)
+	return labels[0], nil
+}
+
+// commitVersion commits version.rb of a package which has a given version on top of the parent commit
+func (p *Proposer) commitVersion(pkg *Package, parent, version, message string) (string, error) {
+	path := pkg.Path
+
+	opt := github.RepositoryContentGetOptions{Ref: parent}
+	fc, _, err := p.GetContent(path, &opt)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := decodeContent(fc)
+	if err != nil {
+		return "", err
+	}
+
+	updated, err := replaceVersion(pkg, content, version)
+	if err != nil {
+		return "", err
+	}
+
+	commit, err := p.GetCommit(parent)
+	if err != nil {
+		return "", err
+	}
+
+	entry := github.TreeEntry{Path: github.String(path), Mode: github.String("100644"), Type: github.String("blob"), Content: github.String(updated)}
+	tree, err := p.CreateTree(commit.GetTree().GetSHA(), []github.TreeEntry{entry})
+	if err != nil {
+		return "", err
+	}
+
+	created, err := p.CreateCommit(&github.Commit{
+		Message: github.String(message),
+		Tree:    &github.Tree{SHA: tree.SHA},
+		Parents: []github.Commit{{SHA: github.String(parent)}},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return created.GetSHA(), nil
+}
+
+// replaceVersion rewrites the version of the version file of a package in place, keeping everything around it
+func replaceVersion(pkg *Package, content, version string) (string, error) {
+	m, ok := pkg.extractVersion(content)
+	if !ok {
+		return "", fmt.Errorf("could not find the version in %s", pkg.fileName())
+	}
+
+	return m.replace(content, version), nil
+}
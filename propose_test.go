@@ -0,0 +1,224 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestReplaceVersion(t *testing.T) {
+	cases := []struct {
+		content  string
+		expected string
+	}{
+		{content: "module BumpReviewer\n  VERSION = \"1.0.1\"\nend\n", expected: "module BumpReviewer\n  VERSION = \"1.0.2\"\nend\n"},
+		{content: "module BumpReviewer\n  VERSION = '1.0.1'.freeze\nend\n", expected: "module BumpReviewer\n  VERSION = '1.0.2'.freeze\nend\n"},
+		{content: "module BumpReviewer\n\tVERSION=\"1.0.1\"\nend", expected: "module BumpReviewer\n\tVERSION=\"1.0.2\"\nend"},
+	}
+
+	reviewer := Reviewer{GitHubClient: NewGitHubClient(testGitHubOwner, testGitHubRepo, testGitHubToken)}
+	pkg := DefaultConfig().packages(testGitHubRepo)[0]
+
+	for i, tc := range cases {
+		got, err := replaceVersion(pkg, tc.content, "1.0.2")
+		if err != nil {
+			t.Fatalf("#%d replaceVersion returned unexpected error: %s", i, err)
+		}
+
+		if got != tc.expected {
+			t.Errorf("#%d replaceVersion returned %q, want %q", i, got, tc.expected)
+		}
+
+		// The proposed version.rb passes the review by construction
+		var table versionTable
+		if err := reviewer.checkVersion(pkg, "1.0.1", got, []string{BumpPatch}, &table); err != nil {
+			t.Errorf("#%d Reviewer.checkVersion rejected the proposed version.rb: %s", i, err)
+		}
+	}
+
+	if _, err := replaceVersion(pkg, "module BumpReviewer\nend\n", "1.0.2"); err == nil {
+		t.Errorf("replaceVersion did not return an error for version.rb without VERSION")
+	}
+}
+
+func TestBumpLabel(t *testing.T) {
+	conf := DefaultConfig()
+	conf.BumpLabels = map[string]string{"bump:minor": BumpMinor, "feature": BumpMinor}
+
+	cases := []struct {
+		kind     string
+		expected string
+		err      bool
+	}{
+		{kind: BumpPatch, expected: ""},
+		{kind: BumpMinor, expected: "bump:minor"},
+		{kind: BumpMajor, err: true},
+	}
+
+	for i, tc := range cases {
+		got, err := bumpLabel(conf, tc.kind)
+		if tc.err != (err != nil) {
+			t.Fatalf("#%d bumpLabel returned unexpected error: %v", i, err)
+		}
+
+		if got != tc.expected {
+			t.Errorf("#%d bumpLabel returned %q, want %q", i, got, tc.expected)
+		}
+	}
+}
+
+func TestProposer_Propose(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"default_branch":"master"}`)
+	})
+	setReleaseHandler(mux, "v1.0.1")
+	setGetContentHandler(mux, "1.0.1")
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/refs/heads/bump-reviewer/v1.1.0", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/refs/heads/master", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ref":"refs/heads/master","object":{"sha":"parent123"}}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/commits/parent123", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"sha":"parent123","tree":{"sha":"tree123"}}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/trees", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+
+		var body struct {
+			BaseTree string             `json:"base_tree"`
+			Tree     []github.TreeEntry `json:"tree"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request: %s", err)
+		}
+
+		want := []github.TreeEntry{{Path: github.String("lib/bump-reviewer/version.rb"), Mode: github.String("100644"), Type: github.String("blob"), Content: github.String("\nmodule BumpReviewer\n  VERSION=\"1.1.0\"\nend\n")}}
+		if body.BaseTree != "tree123" || !reflect.DeepEqual(body.Tree, want) {
+			t.Errorf("Proposer.Propose created unexpected tree: %s %+v", body.BaseTree, body.Tree)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"sha":"newtree123"}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/commits", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, `{"message":"Bump up to v1.1.0","tree":"newtree123","parents":["parent123"]}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"sha":"commit123"}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/refs", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, `{"ref":"refs/heads/bump-reviewer/v1.1.0","sha":"commit123"}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"ref":"refs/heads/bump-reviewer/v1.1.0"}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/pulls", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, `{"title":"Bump up to v1.1.0","head":"bump-reviewer/v1.1.0","base":"master","body":"bump-reviewer bumps minor version from 1.0.1 to 1.1.0."}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"number":5}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/issues/5/labels", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, `["bumpup","bump:minor"]`+"\n")
+		fmt.Fprint(w, `[{"name":"bumpup"},{"name":"bump:minor"}]`)
+	})
+
+	conf := DefaultConfig()
+	conf.BumpLabels = map[string]string{"bump:minor": BumpMinor}
+	proposer := Proposer{GitHubClient: client, Config: conf, Labels: []string{"bumpup"}}
+
+	pr, err := proposer.Propose(BumpMinor)
+	if err != nil {
+		t.Fatalf("Proposer.Propose returned unexpected error: %s", err)
+	}
+
+	if got := pr.GetNumber(); got != 5 {
+		t.Errorf("Proposer.Propose returned Pull Request #%d, want #5", got)
+	}
+}
+
+func TestProposer_Propose_BranchExists(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"default_branch":"master"}`)
+	})
+	setReleaseHandler(mux, "v1.0.1")
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/refs/heads/bump-reviewer/v1.0.2", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ref":"refs/heads/bump-reviewer/v1.0.2","object":{"sha":"commit123"}}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/pulls", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Proposer.Propose opened a Pull Request although the branch exists")
+	})
+
+	proposer := Proposer{GitHubClient: client}
+	if _, err := proposer.Propose(BumpPatch); err == nil {
+		t.Errorf("Proposer.Propose did not return an error")
+	}
+}
+
+func TestProposer_Propose_ReviewerToken(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	setAuthenticatedUserHandler(mux, "bump-reviewer-bot", "repo")
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Proposer.Propose went on although the token belongs to the reviewer")
+	})
+
+	proposer := Proposer{GitHubClient: client, Reviewer: "Bump-Reviewer-Bot"}
+	_, err := proposer.Propose(BumpPatch)
+	if err == nil || !strings.Contains(err.Error(), "belongs to @bump-reviewer-bot, who reviews the Pull Request") {
+		t.Errorf("Proposer.Propose returned unexpected error: %v", err)
+	}
+}
+
+func TestProposer_Propose_TagAndRegistryBaseline(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	os.Setenv("BUMP_REVIEWER_TEST_REGISTRY_KEY", "registry-key")
+	defer os.Unsetenv("BUMP_REVIEWER_TEST_REGISTRY_KEY")
+
+	registry := setupRegistry(`[{"number":"1.0.5"},{"number":"1.0.1"}]`)
+	defer registry.Close()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"default_branch":"master"}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/releases", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"tag_name":"release-1.0.1"}]`)
+	})
+
+	// The branch of the version after the published one is named by the tag template
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/refs/heads/bump-reviewer/release-1.0.6", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ref":"refs/heads/bump-reviewer/release-1.0.6","object":{"sha":"commit123"}}`)
+	})
+
+	conf := DefaultConfig()
+	conf.Tag.Template = "release-{{.Version}}"
+	conf.Registry = RegistryConfig{URL: registry.URL, TokenEnv: "BUMP_REVIEWER_TEST_REGISTRY_KEY", Baseline: true}
+	if err := conf.init(); err != nil {
+		t.Fatalf("Config.init returned unexpected error: %s", err)
+	}
+
+	proposer := Proposer{GitHubClient: client, Config: conf}
+	_, err := proposer.Propose(BumpPatch)
+	if err == nil || !strings.Contains(err.Error(), "branch bump-reviewer/release-1.0.6 already exists, a Pull Request to bump up to release-1.0.6") {
+		t.Errorf("Proposer.Propose returned unexpected error: %v", err)
+	}
+}
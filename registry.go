@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const CheckRegistry = "registry"
+
+// gemVersion is a version in the response of /api/v1/versions/<gem>.json
+type gemVersion struct {
+	Number     string `json:"number"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+var registryHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// publishedVersions returns the versions of the gem of a package published to the registry
+func publishedVersions(pkg *Package) ([]gemVersion, error) {
+	conf := pkg.config.Registry
+
+	gem := conf.Gem
+	if gem == "" {
+		gem = pkg.Name
+	}
+
+	url := fmt.Sprintf("%s/api/v1/versions/%s.json", strings.TrimSuffix(conf.URL, "/"), gem)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	if conf.TokenEnv != "" {
+		req.Header.Set("Authorization", os.Getenv(conf.TokenEnv))
+	}
+
+	res, err := registryHTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// The gem has never been published
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returns invalid status: %s", url, res.Status)
+	}
+
+	var versions []gemVersion
+	if err := json.NewDecoder(res.Body).Decode(&versions); err != nil {
+		return nil, fmt.Errorf("failed to parse the response of %s: %s", url, err)
+	}
+
+	return versions, nil
+}
+
+// reviewRegistry checks if a version has not been published to the registry yet
+func (r *Reviewer) reviewRegistry(pkg *Package, version string) error {
+	published, err := publishedVersions(pkg)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range published {
+		if p.Number == version {
+			return &reviewError{Check: CheckRegistry, Message: fmt.Sprintf("Version %s has already been published to %s. bump-reviewer expects a version which has never been published.", version, pkg.config.Registry.URL)}
+		}
+	}
+
+	return nil
+}
+
+// registryBaseline returns the highest published version of a package if it is higher than the baseline.
+// Only versions of the release line count when a line is given.
+func registryBaseline(pkg *Package, scheme versionScheme, baseline string, line *versionLine) (string, error) {
+	highest, err := scheme.normalize(baseline)
+	if err != nil {
+		return "", err
+	}
+
+	published, err := publishedVersions(pkg)
+	if err != nil {
+		return "", err
+	}
+
+	result := baseline
+	for _, p := range published {
+		if p.Prerelease {
+			continue
+		}
+
+		v, err := scheme.normalize(p.Number)
+		if err != nil || (line != nil && !line.contains(v)) {
+			continue
+		}
+
+		if scheme.compare(v, highest) > 0 {
+			highest = v
+			result = p.Number
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+// setupRegistry starts a stand-in of a RubyGems compatible registry which has given versions of bump-reviewer gem
+func setupRegistry(versions string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "registry-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if r.URL.Path != "/api/v1/versions/bump-reviewer.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		fmt.Fprint(w, versions)
+	}))
+}
+
+func testRegistryReviewer(url string) *Reviewer {
+	reviewer := &Reviewer{GitHubClient: NewGitHubClient(testGitHubOwner, testGitHubRepo, testGitHubToken), Config: DefaultConfig()}
+	reviewer.Config.Registry = RegistryConfig{URL: url, TokenEnv: "BUMP_REVIEWER_TEST_REGISTRY_KEY"}
+	return reviewer
+}
+
+func TestPublishedVersions(t *testing.T) {
+	os.Setenv("BUMP_REVIEWER_TEST_REGISTRY_KEY", "registry-key")
+	defer os.Unsetenv("BUMP_REVIEWER_TEST_REGISTRY_KEY")
+
+	registry := setupRegistry(`[{"number":"1.1.0.pre","prerelease":true},{"number":"1.0.2"},{"number":"1.0.1"}]`)
+	defer registry.Close()
+
+	reviewer := testRegistryReviewer(registry.URL + "/")
+
+	got, err := publishedVersions(testPackage(reviewer))
+	if err != nil {
+		t.Fatalf("publishedVersions returned unexpected error: %s", err)
+	}
+
+	want := []gemVersion{{Number: "1.1.0.pre", Prerelease: true}, {Number: "1.0.2"}, {Number: "1.0.1"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("publishedVersions returned %v, want %v", got, want)
+	}
+
+	// Gems which have never been published have no versions
+	reviewer.Config.Registry.Gem = "unpublished"
+	if got, err := publishedVersions(testPackage(reviewer)); err != nil || len(got) != 0 {
+		t.Errorf("publishedVersions returned %v, %v for an unpublished gem", got, err)
+	}
+}
+
+func TestPublishedVersions_Unauthorized(t *testing.T) {
+	registry := setupRegistry(`[]`)
+	defer registry.Close()
+
+	reviewer := testRegistryReviewer(registry.URL)
+	if _, err := publishedVersions(testPackage(reviewer)); err == nil {
+		t.Errorf("publishedVersions did not return an error without the key")
+	}
+}
+
+func TestReviewer_ReviewRegistry(t *testing.T) {
+	os.Setenv("BUMP_REVIEWER_TEST_REGISTRY_KEY", "registry-key")
+	defer os.Unsetenv("BUMP_REVIEWER_TEST_REGISTRY_KEY")
+
+	registry := setupRegistry(`[{"number":"1.0.2"},{"number":"1.0.1"}]`)
+	defer registry.Close()
+
+	reviewer := testRegistryReviewer(registry.URL)
+
+	if err := reviewer.reviewRegistry(testPackage(reviewer), "1.0.3"); err != nil {
+		t.Errorf("Reviewer.reviewRegistry returned unexpected error: %s", err)
+	}
+
+	err := reviewer.reviewRegistry(testPackage(reviewer), "1.0.2")
+	if re, ok := err.(*reviewError); !ok || re.Check != CheckRegistry {
+		t.Errorf("Reviewer.reviewRegistry returned %v, want reviewError of %s check", err, CheckRegistry)
+	}
+}
+
+func TestRegistryBaseline(t *testing.T) {
+	os.Setenv("BUMP_REVIEWER_TEST_REGISTRY_KEY", "registry-key")
+	defer os.Unsetenv("BUMP_REVIEWER_TEST_REGISTRY_KEY")
+
+	registry := setupRegistry(`[{"number":"1.1.0-beta.1","prerelease":true},{"number":"1.0.2"},{"number":"1.0.1"}]`)
+	defer registry.Close()
+
+	reviewer := testRegistryReviewer(registry.URL)
+
+	cases := []struct {
+		baseline string
+		expected string
+	}{
+		{baseline: "1.0.1", expected: "1.0.2"},
+		{baseline: "1.0.3", expected: "1.0.3"},
+	}
+
+	for i, tc := range cases {
+		got, err := registryBaseline(testPackage(reviewer), semverScheme{}, tc.baseline, nil)
+		if err != nil {
+			t.Fatalf("#%d registryBaseline returned unexpected error: %s", i, err)
+		}
+
+		if got != tc.expected {
+			t.Errorf("#%d registryBaseline returned %s, want %s", i, got, tc.expected)
+		}
+	}
+}
+
+func TestReviewer_ReviewVersion_MaintenanceRegistryBaseline(t *testing.T) {
+	os.Setenv("BUMP_REVIEWER_TEST_REGISTRY_KEY", "registry-key")
+	defer os.Unsetenv("BUMP_REVIEWER_TEST_REGISTRY_KEY")
+
+	// 2.0.1 of the default branch is the highest published version, but 1.2.5 is of the maintenance branch's line
+	registry := setupRegistry(`[{"number":"2.0.1"},{"number":"1.2.5"},{"number":"1.2.3"}]`)
+	defer registry.Close()
+
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/releases", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"tag_name":"v2.0.0"},{"tag_name":"v1.2.3"}]`)
+	})
+
+	reviewer.Config = DefaultConfig()
+	reviewer.Config.MaintenanceBranches = []string{"*-stable"}
+	reviewer.Config.Registry = RegistryConfig{URL: registry.URL, TokenEnv: "BUMP_REVIEWER_TEST_REGISTRY_KEY", Baseline: true}
+
+	cases := []struct {
+		found    string
+		rejected bool
+	}{
+		{found: "1.2.6"},
+		{found: "1.2.4", rejected: true},
+		{found: "2.0.2", rejected: true},
+	}
+
+	versions := map[string]string{}
+	setVersionAtRefHandler(mux, versions)
+
+	for i, tc := range cases {
+		versions["pull/1/head"] = tc.found
+		pr := &github.PullRequest{Number: github.Int(1), Base: &github.PullRequestBranch{Ref: github.String("1-2-stable")}}
+		result := &ReviewResult{Number: 1, BumpKinds: []string{BumpPatch}}
+
+		err := reviewer.reviewVersion(pr, testPackage(reviewer), &github.CommitFile{}, result)
+		if tc.rejected {
+			if re, ok := err.(*reviewError); !ok || re.Check != CheckVersion {
+				t.Errorf("#%d Reviewer.reviewVersion returned %v for %s, want reviewError of %s check", i, err, tc.found, CheckVersion)
+			}
+		} else if err != nil {
+			t.Errorf("#%d Reviewer.reviewVersion returned unexpected error for %s: %s", i, tc.found, err)
+		}
+
+		if result.Versions.Baseline != "1.2.5" {
+			t.Errorf("#%d Reviewer.reviewVersion compared %s to %s, want 1.2.5", i, tc.found, result.Versions.Baseline)
+		}
+	}
+}
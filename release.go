@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+type releaseError struct {
+	Message string
+}
+
+func (r *releaseError) Error() string {
+	return r.Message
+}
+
+// Releaser creates the tag and the GitHub Release of merged bump up PRs
+type Releaser struct {
+	*GitHubClient
+
+	// Config is the configuration the PRs were reviewed with
+	Config *Config
+}
+
+// Release creates the tags and the GitHub Releases of the packages a merged bump up PR bumps.
+// It is safe to run more than once, the tags and the releases are created only if they do not exist yet.
+func (r *Releaser) Release(number int) ([]*github.RepositoryRelease, error) {
+	pr, err := r.GetPullRequest(number)
+	if err != nil {
+		return nil, err
+	}
+
+	if !pr.GetMerged() {
+		return nil, &releaseError{Message: fmt.Sprintf("Pull Request #%d has not been merged yet", number)}
+	}
+
+	user, _, err := r.GetAuthenticatedUser()
+	if err != nil {
+		return nil, err
+	}
+
+	approved, err := r.approvedCommit(number, user.GetLogin())
+	if err != nil {
+		return nil, err
+	}
+
+	pkgs, err := r.bumpedPackages(number)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []*github.RepositoryRelease
+	for _, pkg := range pkgs {
+		release, err := r.releasePackage(pr, pkg, approved)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, release)
+	}
+
+	return releases, nil
+}
+
+// releasePackage creates the tag and the GitHub Release of a package bumped by a merged PR
+func (r *Releaser) releasePackage(pr *github.PullRequest, pkg *Package, approvedCommit string) (*github.RepositoryRelease, error) {
+	sha := pr.GetMergeCommitSHA()
+
+	approved, err := r.versionAt(pkg, approvedCommit)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := r.versionAt(pkg, sha)
+	if err != nil {
+		return nil, err
+	}
+
+	if merged != approved {
+		return nil, &releaseError{Message: fmt.Sprintf("%s of the merged commit %s has version %s, but version %s was approved in Pull Request #%d", pkg.Path, sha, merged, approved, pr.GetNumber())}
+	}
+
+	tag, err := pkg.formatTag(merged)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.createTag(tag, sha); err != nil {
+		return nil, err
+	}
+
+	release, err := r.GetReleaseByTag(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	if release != nil {
+		return release, nil
+	}
+
+	body, err := r.releaseNotes(pr, pkg, sha)
+	if err != nil {
+		return nil, err
+	}
+
+	// A backport to an older release line must not become the latest release
+	latest := !isMaintenanceBranch(pkg.config, pr.GetBase().GetRef())
+
+	return r.CreateRelease(&github.RepositoryRelease{
+		TagName: github.String(tag),
+		Name:    github.String(tag),
+		Body:    github.String(body),
+	}, latest)
+}
+
+// bumpedPackages returns the packages whose version.rb a PR changes.
+// Without packages configured, it is the repository itself.
+func (r *Releaser) bumpedPackages(number int) ([]*Package, error) {
+	if len(configOrDefault(r.Config).Packages) == 0 {
+		return configOrDefault(r.Config).packages(r.Repo), nil
+	}
+
+	files, err := r.ListPullRequestsFiles(number, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgs []*Package
+	for _, f := range files {
+		if pkg := configOrDefault(r.Config).packageAt(r.Repo, f.GetFilename()); pkg != nil {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+
+	if len(pkgs) == 0 {
+		return nil, &releaseError{Message: fmt.Sprintf("Pull Request #%d does not change version.rb of any package", number)}
+	}
+
+	return pkgs, nil
+}
+
+// approvedCommit returns the latest head of the PR which bump-reviewer approved as a given login.
+// Approvals bump-reviewer dismissed are no longer APPROVED, so they do not count.
+func (r *Releaser) approvedCommit(number int, login string) (string, error) {
+	reviews, err := r.ListReviews(number, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return "", err
+	}
+
+	var approved *github.PullRequestReview
+	for _, review := range reviews {
+		if review.GetState() == ReviewStateApproved && strings.EqualFold(review.GetUser().GetLogin(), login) {
+			approved = review
+		}
+	}
+
+	if approved == nil {
+		return "", &releaseError{Message: fmt.Sprintf("Pull Request #%d has not been approved by @%s", number, login)}
+	}
+
+	return approved.GetCommitID(), nil
+}
+
+// versionAt returns the version in the version file of a package at a given ref
+func (r *Releaser) versionAt(pkg *Package, ref string) (string, error) {
+	opt := github.RepositoryContentGetOptions{Ref: ref}
+	fc, _, err := r.GetContent(pkg.Path, &opt)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := decodeContent(fc)
+	if err != nil {
+		return "", err
+	}
+
+	version := pkg.parseVersion(content)
+	if version == "" {
+		return "", &releaseError{Message: fmt.Sprintf("%s at %s does not have a version", pkg.Path, ref)}
+	}
+
+	return version, nil
+}
+
+// createTag creates a tag on a given commit unless it already exists there
+func (r *Releaser) createTag(tag, sha string) error {
+	ref, err := r.GetRef("tags/" + tag)
+	if err != nil {
+		return err
+	}
+
+	if ref != nil {
+		target := ref.GetObject().GetSHA()
+
+		// Annotated tags point to tag objects, which point to the commits
+		if ref.GetObject().GetType() == "tag" {
+			t, err := r.GetTag(target)
+			if err != nil {
+				return err
+			}
+			target = t.GetObject().GetSHA()
+		}
+
+		if target != sha {
+			return &releaseError{Message: fmt.Sprintf("tag %s already exists on another commit %s", tag, target)}
+		}
+		return nil
+	}
+
+	_, err = r.CreateRef(&github.Reference{Ref: github.String("refs/tags/" + tag), Object: &github.GitObject{SHA: github.String(sha)}})
+	return err
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func setupReleaser(mux *http.ServeMux) {
+	setPullRequestHandler(mux, 1, `{"number":1,"merged":true,"merge_commit_sha":"merged123"}`)
+	setAuthenticatedUserHandler(mux, testReviewerLogin, "repo")
+	setReviewsHandler(mux, 1, "", `[{"id":1,"state":"APPROVED","commit_id":"head123","user":{"login":"bump-reviewer-bot"}},{"id":2,"state":"APPROVED","commit_id":"head456","user":{"login":"someone"}}]`)
+	setVersionAtRefHandler(mux, map[string]string{"head123": "1.0.1", "merged123": "1.0.1"})
+	setReleaseHandler(mux, "v1.0.0")
+	setCompareHandler(mux, "v1.0.0", "merged123", `{"commits":[{"commit":{"message":"Bump up to v1.0.1 (#1)"}}]}`)
+}
+
+func TestReleaser_Release(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	setupReleaser(mux)
+
+	var refCreated, releaseCreated bool
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/refs/tags/v1.0.1", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/refs", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, `{"ref":"refs/tags/v1.0.1","sha":"merged123"}`+"\n")
+		refCreated = true
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"ref":"refs/tags/v1.0.1","object":{"sha":"merged123"}}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/releases/tags/v1.0.1", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/releases", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPost)
+		testBody(t, r, `{"tag_name":"v1.0.1","name":"v1.0.1","body":"There are no Pull Requests merged since v1.0.0.\n"}`+"\n")
+		releaseCreated = true
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"tag_name":"v1.0.1"}`)
+	})
+
+	releaser := Releaser{GitHubClient: client}
+	releases, err := releaser.Release(1)
+	if err != nil {
+		t.Fatalf("Releaser.Release returned unexpected error: %s", err)
+	}
+
+	if !refCreated || !releaseCreated {
+		t.Errorf("Releaser.Release created tag: %t, release: %t, want both of them", refCreated, releaseCreated)
+	}
+
+	if len(releases) != 1 || releases[0].GetTagName() != "v1.0.1" {
+		t.Errorf("Releaser.Release returned %v, want a release of v1.0.1", releases)
+	}
+}
+
+func TestReleaser_Release_AlreadyReleased(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	setupReleaser(mux)
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/refs/tags/v1.0.1", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ref":"refs/tags/v1.0.1","object":{"sha":"merged123"}}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/refs", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Releaser.Release created the tag which already exists")
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/releases/tags/v1.0.1", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"tag_name":"v1.0.1"}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/releases", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Releaser.Release created the release which already exists")
+	})
+
+	releaser := Releaser{GitHubClient: client}
+	if _, err := releaser.Release(1); err != nil {
+		t.Fatalf("Releaser.Release returned unexpected error: %s", err)
+	}
+}
+
+func TestReleaser_Release_Fail(t *testing.T) {
+	cases := []struct {
+		pr       string
+		reviews  string
+		versions map[string]string
+		tagSHA   string
+	}{
+		{pr: `{"number":1,"merged":false}`, reviews: `[]`},
+		{pr: `{"number":1,"merged":true,"merge_commit_sha":"merged123"}`, reviews: `[{"id":1,"state":"COMMENTED","commit_id":"head123"}]`},
+		{pr: `{"number":1,"merged":true,"merge_commit_sha":"merged123"}`, reviews: `[{"id":1,"state":"APPROVED","commit_id":"head123","user":{"login":"someone"}}]`, versions: map[string]string{"head123": "1.0.1", "merged123": "1.0.1"}},
+		{pr: `{"number":1,"merged":true,"merge_commit_sha":"merged123"}`, reviews: `[{"id":1,"state":"DISMISSED","commit_id":"head123","user":{"login":"bump-reviewer-bot"}}]`, versions: map[string]string{"head123": "1.0.1", "merged123": "1.0.1"}},
+		{pr: `{"number":1,"merged":true,"merge_commit_sha":"merged123"}`, reviews: `[{"id":1,"state":"APPROVED","commit_id":"head123","user":{"login":"bump-reviewer-bot"}}]`, versions: map[string]string{"head123": "1.0.1", "merged123": "1.0.2"}},
+		{pr: `{"number":1,"merged":true,"merge_commit_sha":"merged123"}`, reviews: `[{"id":1,"state":"APPROVED","commit_id":"head123","user":{"login":"bump-reviewer-bot"}}]`, versions: map[string]string{"head123": "1.0.1", "merged123": "1.0.1"}, tagSHA: "other123"},
+	}
+
+	for i, tc := range cases {
+		client, mux, _, tearDown := setup()
+
+		setPullRequestHandler(mux, 1, tc.pr)
+		setAuthenticatedUserHandler(mux, testReviewerLogin, "repo")
+		setReviewsHandler(mux, 1, "", tc.reviews)
+		setVersionAtRefHandler(mux, tc.versions)
+		mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/refs/tags/v1.0.1", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"ref":"refs/tags/v1.0.1","object":{"sha":"%s"}}`, tc.tagSHA)
+		})
+
+		releaser := Releaser{GitHubClient: client}
+		_, err := releaser.Release(1)
+		if _, ok := err.(*releaseError); !ok {
+			t.Errorf("#%d Releaser.Release returned %v, want releaseError", i, err)
+		}
+
+		tearDown()
+	}
+}
+
+func TestReleaser_Release_AnnotatedTag(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	setupReleaser(mux)
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/refs/tags/v1.0.1", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ref":"refs/tags/v1.0.1","object":{"sha":"tag123","type":"tag"}}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/tags/tag123", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"sha":"tag123","object":{"sha":"merged123","type":"commit"}}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/releases/tags/v1.0.1", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"tag_name":"v1.0.1"}`)
+	})
+
+	releaser := Releaser{GitHubClient: client}
+	if _, err := releaser.Release(1); err != nil {
+		t.Fatalf("Releaser.Release returned unexpected error: %s", err)
+	}
+}
+
+func TestReleaser_Release_MaintenanceBranch(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	setPullRequestHandler(mux, 1, `{"number":1,"merged":true,"merge_commit_sha":"merged123","base":{"ref":"1-0-stable"}}`)
+	setAuthenticatedUserHandler(mux, testReviewerLogin, "repo")
+	setReviewsHandler(mux, 1, "", `[{"id":1,"state":"APPROVED","commit_id":"head123","user":{"login":"bump-reviewer-bot"}}]`)
+	setVersionAtRefHandler(mux, map[string]string{"head123": "1.0.1", "merged123": "1.0.1"})
+	setCompareHandler(mux, "v1.0.0", "merged123", `{"commits":[{"commit":{"message":"Bump up to v1.0.1 (#1)"}}]}`)
+
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/refs/tags/v1.0.1", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ref":"refs/tags/v1.0.1","object":{"sha":"merged123"}}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/releases/tags/v1.0.1", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+	})
+
+	var releaseCreated bool
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/releases", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `[{"tag_name":"v2.0.0"},{"tag_name":"v1.0.0"}]`)
+			return
+		}
+
+		// The notes are of the 1.0 line, and the backport does not become the latest release
+		testBody(t, r, `{"tag_name":"v1.0.1","name":"v1.0.1","body":"There are no Pull Requests merged since v1.0.0.\n","make_latest":"false"}`+"\n")
+		releaseCreated = true
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"tag_name":"v1.0.1"}`)
+	})
+
+	conf := DefaultConfig()
+	conf.MaintenanceBranches = []string{"*-stable"}
+
+	releaser := Releaser{GitHubClient: client, Config: conf}
+	if _, err := releaser.Release(1); err != nil {
+		t.Fatalf("Releaser.Release returned unexpected error: %s", err)
+	}
+
+	if !releaseCreated {
+		t.Errorf("Releaser.Release did not create the release")
+	}
+}
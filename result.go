@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/google/go-github/github"
+)
+
+var checkDescriptions = map[string]string{
+	CheckAuthor:       "PR author is allowed to get an approval",
+	CheckFile:         "PR changes only version.rb",
+	CheckVersion:      "PR increments patch version by one",
+	CheckExisting:     "PR version does not exist as a tag or a release yet",
+	CheckRegistry:     "PR version has not been published to the registry yet",
+	CheckChanges:      "Base branch has changes to release since the latest release",
+	CheckCI:           "Required CI checks succeed",
+	CheckCommits:      "PR bumps version enough for the Conventional Commits since the latest release",
+	CheckMergedLabels: "PR bumps version enough for the labels of the PRs merged since the latest release",
+}
+
+// ReviewResult is the structured result of a review, which message templates are executed with
+type ReviewResult struct {
+	Owner, Repo string
+	Number      int
+	Title       string
+	Author      string
+	URL         string
+	HeadSHA     string
+	NodeID      string
+
+	// Package is the name of the package under review when the repository hosts several gems
+	Package string
+
+	// Packages are the results of the packages the PR bumps when the repository hosts several gems
+	Packages []PackageResult
+
+	// Release is the latest release the PR is compared to
+	Release ReleaseResult
+
+	// BumpKinds are the kinds of version bumps the PR is allowed to make
+	BumpKinds []string
+
+	// Versions are the versions bump-reviewer compared
+	Versions versionTable
+
+	// RequiredBump is the version bump the PRs merged since the latest release need
+	RequiredBump RequiredBumpResult
+
+	// CI are the states of the required CI contexts
+	CI []ContextResult
+
+	Checks []CheckResult
+	Passed bool
+
+	// Warnings are problems which do not fail the review
+	Warnings []string
+
+	// Message describes why the review failed
+	Message string
+
+	// descriptions override checkDescriptions for the checks whose description depends on the PR
+	descriptions map[string]string
+}
+
+// PackageResult describes the version bump of a package
+type PackageResult struct {
+	Name         string
+	Release      ReleaseResult
+	BumpKinds    []string
+	Versions     versionTable
+	RequiredBump RequiredBumpResult
+}
+
+// ReleaseResult describes a GitHub release
+type ReleaseResult struct {
+	Tag string
+	URL string
+}
+
+// RequiredBumpResult describes the version bump the merged PRs need
+type RequiredBumpResult struct {
+	Kind string
+
+	// PullRequests are the merged PRs which need the Kind of version bump
+	PullRequests []MergedPullRequest
+}
+
+// MergedPullRequest describes a PR merged since the latest release
+type MergedPullRequest struct {
+	Number int
+	Title  string
+	Labels []string
+}
+
+// CheckResult is the result of a single check
+type CheckResult struct {
+	Name        string
+	Description string
+	Passed      bool
+	Message     string
+}
+
+func newReviewResult(owner, repo string, pr *github.PullRequest) *ReviewResult {
+	return &ReviewResult{
+		Owner:   owner,
+		Repo:    repo,
+		Number:  pr.GetNumber(),
+		Title:   pr.GetTitle(),
+		Author:  pr.GetUser().GetLogin(),
+		URL:     pr.GetHTMLURL(),
+		HeadSHA: pr.GetHead().GetSHA(),
+		NodeID:  pr.GetNodeID(),
+		Passed:  true,
+	}
+}
+
+func (r *ReviewResult) pass(check string) {
+	r.Checks = append(r.Checks, CheckResult{Name: check, Description: r.describe(check), Passed: true})
+}
+
+func (r *ReviewResult) fail(check, message string) {
+	if r.Package != "" {
+		message = fmt.Sprintf("`%s`: %s", r.Package, message)
+	}
+	r.Checks = append(r.Checks, CheckResult{Name: check, Description: r.describe(check), Message: message})
+	r.Passed = false
+	r.Message = message
+}
+
+// describeCheck sets the description of a check for the rest of the review
+func (r *ReviewResult) describeCheck(check, description string) {
+	if r.descriptions == nil {
+		r.descriptions = map[string]string{}
+	}
+	r.descriptions[check] = description
+}
+
+// describe returns the description of a check, prefixed with the package under review if any
+func (r *ReviewResult) describe(check string) string {
+	description, ok := r.descriptions[check]
+	if !ok {
+		description = checkDescriptions[check]
+	}
+
+	if r.Package != "" {
+		return fmt.Sprintf("`%s`: %s", r.Package, description)
+	}
+
+	return description
+}
+
+func (r *ReviewResult) warn(message string) {
+	if r.Package != "" {
+		message = fmt.Sprintf("`%s`: %s", r.Package, message)
+	}
+	r.Warnings = append(r.Warnings, message)
+}
+
+func sampleReviewResult() *ReviewResult {
+	r := &ReviewResult{
+		Owner:     "shuheiktgw",
+		Repo:      "bump-reviewer",
+		Number:    1,
+		Title:     "Bump up version",
+		Author:    "shuheiktgw",
+		URL:       "https://github.com/shuheiktgw/bump-reviewer/pull/1",
+		HeadSHA:   "0123456789abcdef0123456789abcdef01234567",
+		Release:   ReleaseResult{Tag: "v1.0.1", URL: "https://github.com/shuheiktgw/bump-reviewer/releases/tag/v1.0.1"},
+		BumpKinds: []string{BumpPatch},
+		Versions: versionTable{
+			Baseline: "1.0.1",
+			Kinds:    []string{BumpPatch},
+			Accepted: []string{"1.0.2"},
+			Found:    "1.0.2",
+		},
+		Passed: true,
+	}
+	r.pass(CheckFile)
+	r.pass(CheckVersion)
+
+	return r
+}
@@ -5,10 +5,14 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
-	"github.com/blang/semver"
 	"github.com/google/go-github/github"
-	"github.com/iancoleman/strcase"
+)
+
+const (
+	CheckFile    = "changed files"
+	CheckVersion = "version"
 )
 
 type review interface {
@@ -16,7 +20,9 @@ type review interface {
 }
 
 type reviewError struct {
-	Message string
+	Check    string
+	Message  string
+	Comments []*github.DraftReviewComment
 }
 
 func (r *reviewError) Error() string {
@@ -27,58 +33,288 @@ func (r *reviewError) review() string {
 	return r.Message
 }
 
+// Reasons to skip reviews
+const (
+	SkipLabel      = "label"
+	SkipDraft      = "draft"
+	SkipClosed     = "closed"
+	SkipMerged     = "merged"
+	SkipBaseBranch = "base branch"
+)
+
+type skip interface {
+	skip() string
+	reason() string
+}
+
+type skipError struct {
+	Reason  string
+	Message string
+}
+
+func (s *skipError) Error() string {
+	return s.Message
+}
+
+func (s *skipError) skip() string {
+	return s.Message
+}
+
+func (s *skipError) reason() string {
+	return s.Reason
+}
+
 // Reviewer reviews bump up PRs
 type Reviewer struct {
 	*GitHubClient
+
+	// Config is the configuration of the repository, DefaultConfig is used if it is nil
+	Config *Config
+
+	// Sticky makes Reviewer report failures in a single comment edited in place
+	Sticky bool
+
+	// Merge makes Reviewer merge the PR after approving it
+	Merge bool
+
+	// RequiredLabels are labels the PR needs to have to be reviewed
+	RequiredLabels []string
+
+	// RequireAllLabels makes Reviewer require all the RequiredLabels instead of any of them
+	RequireAllLabels bool
+
+	// login and scopes are the identity of the token
+	login  string
+	scopes []string
+
+	// clock and sleeper replace time.Now and time.Sleep in tests
+	clock   func() time.Time
+	sleeper func(time.Duration)
 }
 
 // Review reviews a bump up PR
 func (r *Reviewer) Review(number int) error {
-	// Check if the PR changes only the version.rb file
-	if err := r.reviewFile(number); err != nil {
-		return r.handleReviewError(number, err)
+	if err := r.resolveIdentity(); err != nil {
+		return err
 	}
 
-	// Check if the PR's version.rb follows the expected pattern
-	if err := r.reviewVersion(number); err != nil {
-		return r.handleReviewError(number, err)
+	pr, err := r.GetPullRequest(number)
+	if err != nil {
+		return err
+	}
+	result := newReviewResult(r.Owner, r.Repo, pr)
+
+	// Check if the PR has the labels to be reviewed
+	if err := r.reviewLabels(pr); err != nil {
+		return err
+	}
+
+	// Check if the PR is open and targets one of the allowed base branches
+	if err := r.reviewState(pr); err != nil {
+		return err
+	}
+
+	// Check if the token is able to approve the PR before reviewing anything,
+	// after the PRs bump-reviewer does not review are skipped
+	if err := r.checkToken(pr); err != nil {
+		return err
+	}
+
+	// Check if the author of the PR is allowed to get an approval
+	if configOrDefault(r.Config).Authors.enabled() {
+		if err := r.reviewAuthor(pr); err != nil {
+			return r.handleReviewError(result, err)
+		}
+		result.pass(CheckAuthor)
+	}
+
+	// Check if the PR changes only the version files of the packages
+	targets, err := r.reviewFile(number)
+	if err != nil {
+		return r.handleReviewError(result, err)
+	}
+	if len(configOrDefault(r.Config).Packages) == 0 {
+		result.describeCheck(CheckFile, "PR changes only "+targets[0].pkg.fileName())
+	} else {
+		result.describeCheck(CheckFile, "PR changes only the version files of the packages")
+	}
+	result.pass(CheckFile)
+
+	for _, target := range targets {
+		if err := r.reviewPackage(pr, target, result); err != nil {
+			return r.handleReviewError(result, err)
+		}
+	}
+	result.Package = ""
+
+	// The results of several packages are only in Packages, so templates do not describe the last one of them.
+	// Config.init rejects templates which refer to the cleared fields with multiple_packages review.
+	if len(result.Packages) > 1 {
+		result.Release = ReleaseResult{}
+		result.BumpKinds = nil
+		result.Versions = versionTable{}
+		result.RequiredBump = RequiredBumpResult{}
+	}
+
+	// Wait until the required CI checks succeed
+	if len(configOrDefault(r.Config).CI.RequiredContexts) != 0 {
+		if err := r.reviewCI(result); err != nil {
+			return r.handleReviewError(result, err)
+		}
+		result.pass(CheckCI)
 	}
 
 	// Approve the PR
-	if err := r.approvePullRequest(number); err != nil {
+	if err := r.approvePullRequest(result); err != nil {
 		return err
 	}
 
+	if r.Sticky {
+		if err := r.resolveStickyComment(result); err != nil {
+			return err
+		}
+	}
+
+	if r.Merge {
+		return r.mergePullRequest(result)
+	}
+
 	return nil
 }
 
-func (r *Reviewer) reviewFile(number int) error {
+// packageFile is version.rb of a package changed by the PR
+type packageFile struct {
+	pkg  *Package
+	file *github.CommitFile
+}
+
+func (r *Reviewer) reviewFile(number int) ([]packageFile, error) {
 	files, err := r.ListPullRequestsFiles(number, nil)
 	if err != nil {
+		return nil, err
+	}
+
+	if len(configOrDefault(r.Config).Packages) == 0 {
+		pkg := configOrDefault(r.Config).packages(r.Repo)[0]
+		if len(files) != 1 {
+			return nil, &reviewError{Check: CheckFile, Message: fmt.Sprintf("Pull Request #%d edited more than one file. bump-reviewer only allows to edit one file, which is `%s`.", number, pkg.fileName())}
+		}
+
+		if *files[0].Filename != pkg.Path {
+			return nil, &reviewError{Check: CheckFile, Message: fmt.Sprintf("Pull Request #%d edited unexpected file, bump-reviewer only allows to edit %s.", number, pkg.Path)}
+		}
+
+		return []packageFile{{pkg: pkg, file: files[0]}}, nil
+	}
+
+	var targets []packageFile
+	var names []string
+	for _, f := range files {
+		pkg := configOrDefault(r.Config).packageAt(r.Repo, f.GetFilename())
+		if pkg == nil {
+			return nil, &reviewError{Check: CheckFile, Message: fmt.Sprintf("Pull Request #%d edited unexpected file %s, bump-reviewer only allows to edit the version files of the packages.", number, f.GetFilename())}
+		}
+		targets = append(targets, packageFile{pkg: pkg, file: f})
+		names = append(names, pkg.Name)
+	}
+
+	if len(targets) == 0 {
+		return nil, &reviewError{Check: CheckFile, Message: fmt.Sprintf("Pull Request #%d edited no version file of the packages.", number)}
+	}
+
+	if len(targets) > 1 && configOrDefault(r.Config).MultiplePackages != MultiplePackagesReview {
+		return nil, &reviewError{Check: CheckFile, Message: fmt.Sprintf("Pull Request #%d bumps more than one package: %s. bump-reviewer only allows to bump one package at a time.", number, strings.Join(names, ", "))}
+	}
+
+	return targets, nil
+}
+
+// reviewPackage reviews the version bump of a package against its own baseline and with its own config
+func (r *Reviewer) reviewPackage(pr *github.PullRequest, target packageFile, result *ReviewResult) error {
+	pkg := target.pkg
+	conf := pkg.config
+	if len(configOrDefault(r.Config).Packages) != 0 {
+		result.Package = pkg.Name
+	}
+	result.BumpKinds = bumpKinds(conf, pr)
+	result.RequiredBump = RequiredBumpResult{}
+	result.describeCheck(CheckVersion, "PR "+r.versionScheme(pkg).expect(result.BumpKinds))
+
+	// Check if the PR's version.rb follows the expected pattern
+	if err := r.reviewVersion(pr, pkg, target.file, result); err != nil {
+		return err
+	}
+	result.pass(CheckVersion)
+
+	// Check if the version has not been tagged or released yet
+	if err := r.reviewExisting(pkg, result.Versions.Found); err != nil {
 		return err
 	}
+	result.pass(CheckExisting)
+
+	// Check if the version has not been published to the registry yet
+	if conf.Registry.enabled() {
+		if err := r.reviewRegistry(pkg, result.Versions.Found); err != nil {
+			return err
+		}
+		result.pass(CheckRegistry)
+	}
+
+	// Check if there is something to release since the latest release
+	cc, err := r.reviewChanges(pr, pkg, result.Release.Tag)
+	if err != nil {
+		return err
+	}
+	result.pass(CheckChanges)
+
+	var commits []github.RepositoryCommit
+	if conf.ConventionalCommits.Enabled || len(conf.ChangeLabels) != 0 {
+		if commits, err = packageCommits(r.GitHubClient, pkg, cc, pr.GetBase().GetSHA()); err != nil {
+			return err
+		}
+	}
+
+	// Check if the PR bumps version enough for the commits since the latest release
+	if conf.ConventionalCommits.Enabled {
+		if err := r.reviewCommits(pkg, commits, result); err != nil {
+			return err
+		}
+		result.pass(CheckCommits)
+	}
+
+	// Check if the PR bumps version enough for the labels of the PRs merged since the latest release
+	if len(conf.ChangeLabels) != 0 {
+		if err := r.reviewMergedLabels(pkg, commits, result); err != nil {
+			return err
+		}
+		result.pass(CheckMergedLabels)
+	}
 
-	if len(files) != 1 {
-		return &reviewError{Message: fmt.Sprintf("Pull Request #%d edited more than one file. bump-reviewer only allows to edit one file, which is `version.rb`.", number)}
+	// Check if other open PRs propose a conflicting version
+	if err := r.reviewConcurrent(pr, pkg, result); err != nil {
+		return err
 	}
+	result.pass(CheckConcurrent)
 
-	filename := fmt.Sprintf("lib/%s/version.rb", r.Repo)
-	if *files[0].Filename != filename {
-		return &reviewError{Message: fmt.Sprintf("Pull Request #%d edited unexpected file, bump-reviewer only allows to edit %s.", number, filename)}
+	if result.Package != "" {
+		result.Packages = append(result.Packages, PackageResult{Name: result.Package, Release: result.Release, BumpKinds: result.BumpKinds, Versions: result.Versions, RequiredBump: result.RequiredBump})
 	}
 
 	return nil
 }
 
-func (r *Reviewer) reviewVersion(number int) error {
-	release, err := r.GetLatestRelease()
+func (r *Reviewer) reviewVersion(pr *github.PullRequest, pkg *Package, file *github.CommitFile, result *ReviewResult) error {
+	number := pr.GetNumber()
+
+	release, line, err := baselineRelease(r.GitHubClient, pkg, r.versionScheme(pkg), pr.GetBase())
 	if err != nil {
 		return err
 	}
 	tag := *release.TagName
+	result.Release = ReleaseResult{Tag: tag, URL: release.GetHTMLURL()}
 
 	opt := github.RepositoryContentGetOptions{Ref: fmt.Sprintf("pull/%d/head", number)}
-	fc, _, err := r.GetContent(fmt.Sprintf("lib/%s/version.rb", r.Repo), &opt)
+	fc, _, err := r.GetContent(pkg.Path, &opt)
 	if err != nil {
 		return err
 	}
@@ -88,47 +324,100 @@ func (r *Reviewer) reviewVersion(number int) error {
 		return err
 	}
 
-	// Trim the prefix "v" or "V"
-	trimmedTag := strings.TrimPrefix(tag, "v")
-	trimmedTag = strings.TrimPrefix(trimmedTag, "V")
+	baseline, err := pkg.parseTag(tag)
+	if err != nil {
+		return err
+	}
+	if pkg.config.Registry.enabled() && pkg.config.Registry.Baseline {
+		if baseline, err = registryBaseline(pkg, r.versionScheme(pkg), baseline, line); err != nil {
+			return err
+		}
+	}
 
-	if err := r.checkVersionRegex(trimmedTag, content); err != nil {
+	if err := r.checkVersion(pkg, baseline, content, result.BumpKinds, &result.Versions); err != nil {
+		if re, ok := err.(*reviewError); ok && len(result.Versions.Accepted) == 1 {
+			if m, found := pkg.extractVersion(content); found {
+				if c := versionSuggestion(file, m, content, result.Versions.Accepted[0]); c != nil {
+					re.Comments = append(re.Comments, c)
+				}
+			}
+		}
 		return err
 	}
 
 	return nil
 }
 
-func (r *Reviewer) handleReviewError(number int, err error) error {
-	if review, ok := err.(review); ok {
-		if err := r.postComment(number, review.review()); err != nil {
+func (r *Reviewer) handleReviewError(result *ReviewResult, err error) error {
+	re, ok := err.(*reviewError)
+	if !ok {
+		return err
+	}
+	result.fail(re.Check, re.Message)
+
+	if r.Sticky {
+		if err := r.updateStickyComment(result); err != nil {
+			return err
+		}
+	} else {
+		body, err := render(configOrDefault(r.Config).failure, result)
+		if err != nil {
+			return err
+		}
+
+		if err := r.postComment(result.Number, body, re.Comments); err != nil {
 			return err
 		}
 	}
 
+	if err := r.dismissApprovals(result.Number, re.Check); err != nil {
+		return err
+	}
+
 	return err
 }
 
-func (r *Reviewer) postComment(number int, comment string) error {
-	review := github.PullRequestReviewRequest{Event: github.String(ReviewComment), Body: github.String(comment)}
-	_, err := r.CreateReview(number, &review)
+// dismissApprovals dismisses approvals bump-reviewer made on the previous heads of the PR
+func (r *Reviewer) dismissApprovals(number int, check string) error {
+	reviews, err := r.ListReviews(number, nil)
 	if err != nil {
 		return err
 	}
 
+	message := fmt.Sprintf("bump-reviewer dismissed its approval because the latest changes did not pass the %s check.", check)
+	for _, review := range reviews {
+		if review.GetState() != ReviewStateApproved || review.GetUser().GetLogin() != r.login {
+			continue
+		}
+
+		dismissal := github.PullRequestReviewDismissalRequest{Message: github.String(message)}
+		if _, err := r.DismissReview(number, review.GetID(), &dismissal); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (r *Reviewer) approvePullRequest(number int) error {
-	body := `LGTM
+func (r *Reviewer) postComment(number int, comment string, comments []*github.DraftReviewComment) error {
+	review := github.PullRequestReviewRequest{Event: github.String(ReviewComment), Body: github.String(comment), Comments: comments}
+	_, err := r.CreateReview(number, &review)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
 
-bump-reviewer checks the following two points.
+func (r *Reviewer) approvePullRequest(result *ReviewResult) error {
+	body, err := render(configOrDefault(r.Config).approval, result)
+	if err != nil {
+		return err
+	}
 
-- PR changes only version.rb
-- PR increments patch version by one 
-`
-	approve := github.PullRequestReviewRequest{Event: github.String(ReviewApprove), Body: github.String(body)}
-	_, err := r.CreateReview(number, &approve)
+	// Pin the approval to the reviewed head, so commits pushed during the review are not approved
+	approve := github.PullRequestReviewRequest{CommitID: github.String(result.HeadSHA), Event: github.String(ReviewApprove), Body: github.String(body)}
+	_, err = r.CreateReview(result.Number, &approve)
 	if err != nil {
 		return err
 	}
@@ -136,6 +425,17 @@ bump-reviewer checks the following two points.
 	return nil
 }
 
+// trimTag trims the prefix "v" or "V" of a tag
+func trimTag(tag string) string {
+	trimmed := strings.TrimPrefix(tag, "v")
+	return strings.TrimPrefix(trimmed, "V")
+}
+
+// versionFilePath returns the path of version.rb of a given repository
+func versionFilePath(repo string) string {
+	return fmt.Sprintf("lib/%s/version.rb", repo)
+}
+
 func decodeContent(rc *github.RepositoryContent) (string, error) {
 	if *rc.Encoding != "base64" {
 		return "", fmt.Errorf("unexpected encoding: %s", *rc.Encoding)
@@ -150,22 +450,127 @@ func decodeContent(rc *github.RepositoryContent) (string, error) {
 	return string(decoded), nil
 }
 
-func (r *Reviewer) checkVersionRegex(tag, content string) error {
-	appName := strcase.ToCamel(r.Repo)
+var moduleRegex = regexp.MustCompile(`module\s+([\w:]+)`)
+
+func (r *Reviewer) checkVersion(pkg *Package, tag, content string, kinds []string, table *versionTable) error {
+	file := pkg.fileName()
+	generic := pkg.config.VersionFile.generic()
+	scheme := r.versionScheme(pkg)
 
-	v, err := semver.New(tag)
+	baseline, err := scheme.normalize(tag)
 	if err != nil {
-		return err
+		return fmt.Errorf("could not parse the version %s of the latest release as a %s version: %s", tag, scheme.name(), err)
 	}
 
-	v.Patch = v.Patch + 1
-	newTag := v.String()
+	*table = versionTable{Baseline: tag, Kinds: kinds, expected: scheme.expect(kinds)}
+	var nextErr error
+	for _, kind := range kinds {
+		next, err := scheme.next(baseline, kind)
+		if err != nil {
+			nextErr = err
+			continue
+		}
+		if !table.accepts(next) {
+			table.Accepted = append(table.Accepted, next)
+		}
+	}
 
-	regStr := fmt.Sprintf(`\s*module\s+%s\s+VERSION\s*=\s*['"]%s['"](\.freeze)?\s+end\s*`, appName, newTag)
+	m, ok := pkg.extractVersion(content)
+	if !ok {
+		if generic {
+			return table.reviewError(fmt.Sprintf("bump-reviewer could not find a version in %s with the pattern `%s`.", file, pkg.config.VersionFile.Pattern))
+		}
+		return table.reviewError("bump-reviewer could not find a `VERSION` constant in version.rb.")
+	}
+
+	literal := m.Version
+	table.Found = literal
+	table.Line = m.Line
+
+	found, err := scheme.normalize(literal)
+	if err != nil {
+		return table.reviewError(fmt.Sprintf("bump-reviewer could not parse `%s` in %s as a %s version.", literal, file, scheme.name()))
+	}
+
+	if mm := moduleRegex.FindStringSubmatch(content); !generic && (mm == nil || mm[1] != pkg.Namespace) {
+		namespace := ""
+		if mm != nil {
+			namespace = mm[1]
+		}
+		return table.reviewError(fmt.Sprintf("version.rb defines `VERSION` in module `%s`, but bump-reviewer expects it in module `%s`.", namespace, pkg.Namespace))
+	}
+
+	if reason := scheme.reject(file, found); reason != "" {
+		return table.reviewError(reason)
+	}
+
+	if len(table.Accepted) == 0 && nextErr != nil {
+		return table.reviewError(fmt.Sprintf("bump-reviewer could not find the next version of %s: %s.", tag, nextErr))
+	}
+
+	switch c := scheme.compare(found, baseline); {
+	case c == 0:
+		return table.reviewError(fmt.Sprintf("%s does not change the version from the latest release %s.", file, tag))
+	case c < 0:
+		return table.reviewError(fmt.Sprintf("%s downgrades the version from %s to %s.", file, tag, literal))
+	case !table.accepts(found):
+		return table.reviewError(fmt.Sprintf("%s skips a version, %s is not the next version of %s.", file, literal, tag))
+	}
+
+	// Generic version files may have anything around the version
+	if generic {
+		return nil
+	}
+
+	regStr := fmt.Sprintf(`\s*module\s+%s\s+VERSION\s*=\s*['"]%s['"](\.freeze)?\s+end\s*`, pkg.Namespace, regexp.QuoteMeta(literal))
 	reg := regexp.MustCompile(regStr)
 	if !reg.Match([]byte(content)) {
-		return &reviewError{Message: fmt.Sprintf("version.rb does not match with the following regex: `%s`. bump-reviewer expects you increment patch version by one.", regStr)}
+		return table.reviewError("version.rb has the expected version, but bump-reviewer expects it to contain nothing but `module` and `VERSION` constant.")
 	}
 
 	return nil
 }
+
+// versionTable summarizes versions bump-reviewer compared
+type versionTable struct {
+	Baseline string
+	Kinds    []string
+	Accepted []string
+	Found    string
+
+	// Line is the 1-based line number Found is on
+	Line int
+
+	// expected describes the version bumps of Kinds
+	expected string
+}
+
+func (t versionTable) accepts(version string) bool {
+	for _, a := range t.Accepted {
+		if a == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t versionTable) reviewError(reason string) *reviewError {
+	found := t.Found
+	if found == "" {
+		found = "-"
+	} else if t.Line != 0 {
+		found = fmt.Sprintf("%s (line %d)", found, t.Line)
+	}
+
+	message := fmt.Sprintf(`%s bump-reviewer expects a Pull Request which %s.
+
+| | Version |
+|---|---|
+| Latest release | %s |
+| Accepted | %s |
+| Found | %s |
+`, reason, t.expected, t.Baseline, strings.Join(t.Accepted, ", "), found)
+
+	return &reviewError{Check: CheckVersion, Message: message}
+}
@@ -7,7 +7,7 @@ import (
 )
 
 func TestReviewer_Integration_Review_Success(t *testing.T) {
-	r := Reviewer{integrationGitHubClient}
+	r := Reviewer{GitHubClient: integrationGitHubClient}
 	err := r.Review(3)
 	if err != nil {
 		t.Fatalf("Unexpected error has returned reviewer.Review: %s", err)
@@ -23,7 +23,7 @@ func TestReviewer_Integration_Review_Fail(t *testing.T) {
 	}
 
 	for i, tc := range cases {
-		r := Reviewer{integrationGitHubClient}
+		r := Reviewer{GitHubClient: integrationGitHubClient}
 		err := r.Review(tc.prNum)
 		if _, ok := err.(review); !ok {
 			t.Fatalf("#%d Unexpected error has returned from reviewer.Review: %s", i, err)
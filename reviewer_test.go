@@ -1,6 +1,8 @@
 package main
 
 import (
+	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 )
@@ -10,6 +12,7 @@ func TestReviewer_Review_FailWithTooManyFiles(t *testing.T) {
 	defer tearDown()
 
 	number := 1
+	setPullRequestHandler(mux, number, `{"number":1}`)
 	setPullRequestFilesHandler(mux, number, `[{"filename":"version.rb"}, {"filename":"version_spec.rb"}]`)
 	setCreateReviewHandler(mux, number, "COMMENT")
 
@@ -29,6 +32,7 @@ func TestReviewer_Review_FailWithNonVersionFile(t *testing.T) {
 	defer tearDown()
 
 	number := 1
+	setPullRequestHandler(mux, number, `{"number":1}`)
 	setPullRequestFilesHandler(mux, number, `[{"filename":"test.rb"}]`)
 	setCreateReviewHandler(mux, number, "COMMENT")
 
@@ -48,6 +52,7 @@ func TestReviewer_Review_FailWithNonMatchVersion(t *testing.T) {
 	defer tearDown()
 
 	number := 1
+	setPullRequestHandler(mux, number, `{"number":1}`)
 	setPullRequestFilesHandler(mux, number, `[{"filename":"lib/bump-reviewer/version.rb"}]`)
 	setCreateReviewHandler(mux, number, "COMMENT")
 	setReleaseHandler(mux, "v1.0.1")
@@ -59,7 +64,7 @@ func TestReviewer_Review_FailWithNonMatchVersion(t *testing.T) {
 		t.Fatalf("Reviewer.Review returned unexpected error: %s", err)
 	}
 
-	if !strings.Contains(r.review(), "version.rb does not match with the following regex") {
+	if !strings.Contains(r.review(), "version.rb skips a version") {
 		t.Fatalf("Reviewer.Review returned unexpected error: %s", err)
 	}
 }
@@ -69,13 +74,258 @@ func TestReviewer_Review_Success(t *testing.T) {
 	defer tearDown()
 
 	number := 1
+	setPullRequestHandler(mux, number, `{"number":1}`)
 	setPullRequestFilesHandler(mux, number, `[{"filename":"lib/bump-reviewer/version.rb"}]`)
 	setCreateReviewHandler(mux, number, "COMMENT")
 	setReleaseHandler(mux, "v1.0.1")
 	setGetContentHandler(mux, "1.0.2")
+	setNewVersionHandler(mux)
+	setChangesHandler(mux)
+	setPullRequestsHandler(mux, "[]")
 
 	err := reviewer.Review(number)
 	if err != nil {
 		t.Fatalf("Reviewer.Review returned unexpected error: %s", err)
 	}
 }
+
+func TestReviewer_Review_DismissStaleApproval(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+
+	number := 1
+	setPullRequestHandler(mux, number, `{"number":1}`)
+	setPullRequestFilesHandler(mux, number, `[{"filename":"lib/bump-reviewer/version.rb"}]`)
+	setReviewsHandler(mux, number, "COMMENT", `[
+		{"id":10,"state":"APPROVED","user":{"login":"bump-reviewer-bot"}},
+		{"id":11,"state":"APPROVED","user":{"login":"shuheiktgw"}},
+		{"id":12,"state":"COMMENTED","user":{"login":"bump-reviewer-bot"}}
+	]`)
+	setReleaseHandler(mux, "v1.0.1")
+	setGetContentHandler(mux, "1.0.3")
+
+	var dismissed []string
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/pulls/%d/reviews/", testGitHubOwner, testGitHubRepo, number), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPut)
+		testBody(t, r, `{"message":"bump-reviewer dismissed its approval because the latest changes did not pass the version check."}`+"\n")
+		dismissed = append(dismissed, r.URL.Path)
+		fmt.Fprint(w, `{"state":"DISMISSED"}`)
+	})
+
+	if _, ok := reviewer.Review(number).(review); !ok {
+		t.Fatalf("Reviewer.Review did not return a review error")
+	}
+
+	want := fmt.Sprintf("/repos/%v/%v/pulls/%d/reviews/10/dismissals", testGitHubOwner, testGitHubRepo, number)
+	if len(dismissed) != 1 || dismissed[0] != want {
+		t.Fatalf("Reviewer.Review dismissed unexpected reviews: want: [%s], got: %v", want, dismissed)
+	}
+}
+
+func TestReviewer_Review_FailWithSuggestion(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+
+	number := 1
+	setPullRequestHandler(mux, number, `{"number":1}`)
+	patch := `@@ -1,4 +1,4 @@\n \n module BumpReviewer\n-  VERSION=\"1.0.1\"\n+  VERSION=\"1.0.3\"\n end`
+	setPullRequestFilesHandler(mux, number, fmt.Sprintf(`[{"filename":"lib/bump-reviewer/version.rb","patch":"%s"}]`, patch))
+	setReleaseHandler(mux, "v1.0.1")
+	setGetContentHandler(mux, "1.0.3")
+
+	var body string
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/pulls/%d/reviews", testGitHubOwner, testGitHubRepo, number), func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		body = readBody(t, r)
+		fmt.Fprint(w, `{"state":"COMMENT"}`)
+	})
+
+	if _, ok := reviewer.Review(number).(review); !ok {
+		t.Fatalf("Reviewer.Review did not return a review error")
+	}
+
+	want := `"comments":[{"path":"lib/bump-reviewer/version.rb","position":4,"body":"bump-reviewer expects the version to be 1.0.2.\n\n` + "```" + `suggestion\n  VERSION=\"1.0.2\"\n` + "```" + `"}]`
+	if !strings.Contains(body, want) {
+		t.Fatalf("Reviewer.Review posted unexpected review: want: %s, got: %s", want, body)
+	}
+}
+
+func TestReviewer_CheckVersion(t *testing.T) {
+	cases := []struct {
+		content  string
+		expected string
+	}{
+		{
+			content:  "module BumpReviewer\n  VERSION = '1.0.2'.freeze\nend\n",
+			expected: "",
+		},
+		{
+			content:  "module BumpReviewer\n  VERSION = '1.0.1'\nend\n",
+			expected: "version.rb does not change the version from the latest release 1.0.1.",
+		},
+		{
+			content:  "module BumpReviewer\n  VERSION = '1.0.0'\nend\n",
+			expected: "version.rb downgrades the version from 1.0.1 to 1.0.0.",
+		},
+		{
+			content:  "module BumpReviewer\n  VERSION = '1.0.3'\nend\n",
+			expected: "version.rb skips a version, 1.0.3 is not the next version of 1.0.1.",
+		},
+		{
+			content:  "module BumpReviewer\n  VERSION = '1.1.0'\nend\n",
+			expected: "version.rb skips a version, 1.1.0 is not the next version of 1.0.1.",
+		},
+		{
+			content:  "module BumpReviewerTest\n  VERSION = '1.0.2'\nend\n",
+			expected: "version.rb defines `VERSION` in module `BumpReviewerTest`, but bump-reviewer expects it in module `BumpReviewer`.",
+		},
+		{
+			content:  "module BumpReviewer\n  VERSION = '1.0'\nend\n",
+			expected: "bump-reviewer could not parse `1.0` in version.rb as a semantic version.",
+		},
+		{
+			content:  "module BumpReviewer\nend\n",
+			expected: "bump-reviewer could not find a `VERSION` constant in version.rb.",
+		},
+		{
+			content:  "module BumpReviewer\n  VERSION = '1.0.2'\n  NAME = 'bump'\nend\n",
+			expected: "version.rb has the expected version, but bump-reviewer expects it to contain nothing but `module` and `VERSION` constant.",
+		},
+	}
+
+	r := Reviewer{GitHubClient: NewGitHubClient(testGitHubOwner, testGitHubRepo, testGitHubToken)}
+	for i, tc := range cases {
+		err := r.checkVersion(testPackage(&r), "1.0.1", tc.content, []string{BumpPatch}, &versionTable{})
+		if tc.expected == "" {
+			if err != nil {
+				t.Errorf("#%d Reviewer.checkVersion returned unexpected error: %s", i, err)
+			}
+			continue
+		}
+
+		re, ok := err.(*reviewError)
+		if !ok {
+			t.Errorf("#%d Reviewer.checkVersion returned unexpected error: %v", i, err)
+			continue
+		}
+
+		if !strings.HasPrefix(re.review(), tc.expected) {
+			t.Errorf("#%d Reviewer.checkVersion returned unexpected message: want: %s, got: %s", i, tc.expected, re.review())
+		}
+	}
+}
+
+func TestReviewer_CheckVersion_Table(t *testing.T) {
+	r := Reviewer{GitHubClient: NewGitHubClient(testGitHubOwner, testGitHubRepo, testGitHubToken)}
+	err := r.checkVersion(testPackage(&r), "1.0.1", "module BumpReviewer\n  VERSION = '1.0.3'\nend\n", []string{BumpPatch}, &versionTable{})
+
+	want := `| | Version |
+|---|---|
+| Latest release | 1.0.1 |
+| Accepted | 1.0.2 |
+| Found | 1.0.3 (line 2) |
+`
+	if re, ok := err.(*reviewError); !ok || !strings.HasSuffix(re.review(), want) {
+		t.Fatalf("Reviewer.checkVersion returned unexpected error: want: %s, got: %v", want, err)
+	}
+}
+
+func TestReviewer_Review_SkipWithoutLabel(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+	reviewer.RequiredLabels = []string{"bumpup"}
+
+	number := 1
+	setPullRequestHandler(mux, number, `{"number":1,"labels":[{"name":"bug"}]}`)
+
+	err := reviewer.Review(number)
+	if _, ok := err.(skip); !ok {
+		t.Fatalf("Reviewer.Review returned unexpected error: %v", err)
+	}
+}
+
+func TestReviewer_Review_SuccessWithBumpLabel(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+	reviewer.Config = DefaultConfig()
+	reviewer.Config.BumpLabels = map[string]string{"bump:minor": BumpMinor}
+
+	number := 1
+	setPullRequestHandler(mux, number, `{"number":1,"labels":[{"name":"bump:minor"}]}`)
+	setPullRequestFilesHandler(mux, number, `[{"filename":"lib/bump-reviewer/version.rb"}]`)
+	setReleaseHandler(mux, "v1.0.1")
+	setGetContentHandler(mux, "1.1.0")
+	setNewVersionHandler(mux)
+	setChangesHandler(mux)
+	setPullRequestsHandler(mux, "[]")
+
+	var body string
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/pulls/%d/reviews", testGitHubOwner, testGitHubRepo, number), func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		body = readBody(t, r)
+		fmt.Fprint(w, `{"state":"APPROVED"}`)
+	})
+
+	if err := reviewer.Review(number); err != nil {
+		t.Fatalf("Reviewer.Review returned unexpected error: %s", err)
+	}
+
+	if !strings.Contains(body, "PR increments minor version by one") {
+		t.Errorf("Reviewer.Review approved with unexpected body: %s", body)
+	}
+}
+
+func TestReviewer_Review_FailWithForkedPullRequest(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+	reviewer.Config = DefaultConfig()
+	reviewer.Config.Authors.RejectForks = true
+
+	number := 1
+	setPullRequestHandler(mux, number, `{"number":1,"head":{"repo":{"full_name":"outsider/bump-reviewer"}},"base":{"repo":{"full_name":"shuheiktgw/bump-reviewer"}}}`)
+	setCreateReviewHandler(mux, number, "COMMENT")
+
+	err := reviewer.Review(number)
+	r, ok := err.(review)
+	if !ok {
+		t.Fatalf("Reviewer.Review returned unexpected error: %s", err)
+	}
+
+	if !strings.Contains(r.review(), "comes from a fork") {
+		t.Fatalf("Reviewer.Review returned unexpected error: %s", err)
+	}
+}
+
+func TestReviewer_Review_ApproveReviewedHead(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+
+	number := 1
+	setPullRequestHandler(mux, number, `{"number":1,"head":{"sha":"abc123"}}`)
+	setPullRequestFilesHandler(mux, number, `[{"filename":"lib/bump-reviewer/version.rb"}]`)
+	setReleaseHandler(mux, "v1.0.1")
+	setGetContentHandler(mux, "1.0.2")
+	setNewVersionHandler(mux)
+	setChangesHandler(mux)
+	setPullRequestsHandler(mux, "[]")
+
+	var body string
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/pulls/%d/reviews", testGitHubOwner, testGitHubRepo, number), func(w http.ResponseWriter, r *http.Request) {
+		body = readBody(t, r)
+		fmt.Fprint(w, `{"state":"APPROVED"}`)
+	})
+
+	if err := reviewer.Review(number); err != nil {
+		t.Fatalf("Reviewer.Review returned unexpected error: %s", err)
+	}
+
+	if want := `"commit_id":"abc123"`; !strings.Contains(body, want) {
+		t.Errorf("Reviewer.Review approved without %s: %s", want, body)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+// reviewState checks if the PR is ready to be reviewed
+func (r *Reviewer) reviewState(pr *github.PullRequest) error {
+	number := pr.GetNumber()
+
+	if pr.GetMerged() {
+		return &skipError{Reason: SkipMerged, Message: fmt.Sprintf("Pull Request #%d has already been merged", number)}
+	}
+
+	if pr.GetState() == "closed" {
+		return &skipError{Reason: SkipClosed, Message: fmt.Sprintf("Pull Request #%d is closed", number)}
+	}
+
+	draft, err := r.IsDraftPullRequest(number)
+	if err != nil {
+		return err
+	}
+
+	if draft {
+		return &skipError{Reason: SkipDraft, Message: fmt.Sprintf("Pull Request #%d is a draft, bump-reviewer reviews it once it is ready for review", number)}
+	}
+
+	base := pr.GetBase().GetRef()
+	allowed := configOrDefault(r.Config).BaseBranches
+	if len(allowed) == 0 {
+		allowed = []string{pr.GetBase().GetRepo().GetDefaultBranch()}
+	}
+
+	for _, b := range allowed {
+		if ok, _ := path.Match(b, base); ok {
+			return nil
+		}
+	}
+
+	return &skipError{Reason: SkipBaseBranch, Message: fmt.Sprintf("Pull Request #%d targets %s, but bump-reviewer only reviews Pull Requests to %s", number, base, strings.Join(allowed, ", "))}
+}
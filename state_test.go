@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestReviewer_ReviewState(t *testing.T) {
+	cases := []struct {
+		pr           string
+		baseBranches []string
+		expected     string
+	}{
+		{pr: `{"number":1,"state":"open","base":{"ref":"master","repo":{"default_branch":"master"}}}`, expected: ""},
+		{pr: `{"number":1,"state":"open","draft":true,"base":{"ref":"master","repo":{"default_branch":"master"}}}`, expected: SkipDraft},
+		{pr: `{"number":1,"state":"closed","base":{"ref":"master","repo":{"default_branch":"master"}}}`, expected: SkipClosed},
+		{pr: `{"number":1,"state":"closed","merged":true,"base":{"ref":"master","repo":{"default_branch":"master"}}}`, expected: SkipMerged},
+		{pr: `{"number":1,"state":"open","base":{"ref":"develop","repo":{"default_branch":"master"}}}`, expected: SkipBaseBranch},
+		{pr: `{"number":1,"state":"open","base":{"ref":"1-2-stable","repo":{"default_branch":"master"}}}`, baseBranches: []string{"master", "*-stable"}, expected: ""},
+		{pr: `{"number":1,"state":"open","base":{"ref":"master","repo":{"default_branch":"master"}}}`, baseBranches: []string{"*-stable"}, expected: SkipBaseBranch},
+	}
+
+	for i, tc := range cases {
+		func() {
+			reviewer, mux, _, tearDown := setupReviewer()
+			defer tearDown()
+
+			reviewer.Config = DefaultConfig()
+			reviewer.Config.BaseBranches = tc.baseBranches
+
+			number := 1
+			setPullRequestHandler(mux, number, tc.pr)
+
+			pr, err := reviewer.GetPullRequest(number)
+			if err != nil {
+				t.Fatalf("#%d GitHubClient.GetPullRequest returned unexpected error: %s", i, err)
+			}
+
+			err = reviewer.reviewState(pr)
+			if tc.expected == "" {
+				if err != nil {
+					t.Errorf("#%d Reviewer.reviewState returned unexpected error: %s", i, err)
+				}
+				return
+			}
+
+			s, ok := err.(*skipError)
+			if !ok || s.Reason != tc.expected {
+				t.Errorf("#%d Reviewer.reviewState returned unexpected error: want: %s, got: %v", i, tc.expected, err)
+			}
+		}()
+	}
+}
+
+func TestReviewer_Review_SkipClosedPullRequest(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+
+	number := 1
+	setPullRequestHandler(mux, number, `{"number":1,"state":"closed"}`)
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/pulls/%d/reviews", testGitHubOwner, testGitHubRepo, number), func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Reviewer.Review posted a review on a closed Pull Request")
+	})
+
+	if s, ok := reviewer.Review(number).(*skipError); !ok || s.Reason != SkipClosed {
+		t.Fatalf("Reviewer.Review did not skip a closed Pull Request")
+	}
+}
+
+func TestReviewer_Review_SkipUnlabeledDraftByLabel(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+	reviewer.RequiredLabels = []string{"bumpup"}
+
+	number := 1
+	setPullRequestHandler(mux, number, `{"number":1,"state":"open","draft":true}`)
+
+	if s, ok := reviewer.Review(number).(*skipError); !ok || s.Reason != SkipLabel {
+		t.Fatalf("Reviewer.Review did not skip an unlabeled draft Pull Request for the label")
+	}
+}
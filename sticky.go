@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+// stickyCommentMarker is a hidden HTML comment which identifies the sticky comment
+const stickyCommentMarker = "<!-- bump-reviewer:sticky-comment -->"
+
+// updateStickyComment writes the review failure to the sticky comment, creating it if it does not exist yet
+func (r *Reviewer) updateStickyComment(result *ReviewResult) error {
+	body, err := r.stickyCommentBody(result)
+	if err != nil {
+		return err
+	}
+
+	sc, err := r.findStickyComment(result.Number)
+	if err != nil {
+		return err
+	}
+
+	if sc == nil {
+		_, err := r.CreateIssueComment(result.Number, &github.IssueComment{Body: github.String(body)})
+		return err
+	}
+
+	_, err = r.EditIssueComment(sc.GetID(), &github.IssueComment{Body: github.String(body)})
+	return err
+}
+
+// resolveStickyComment marks the sticky comment as resolved if the PR has one
+func (r *Reviewer) resolveStickyComment(result *ReviewResult) error {
+	sc, err := r.findStickyComment(result.Number)
+	if err != nil {
+		return err
+	}
+
+	if sc == nil {
+		return nil
+	}
+
+	body, err := r.stickyCommentBody(result)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.EditIssueComment(sc.GetID(), &github.IssueComment{Body: github.String(body)})
+	return err
+}
+
+func (r *Reviewer) stickyCommentBody(result *ReviewResult) (string, error) {
+	body, err := render(configOrDefault(r.Config).sticky, result)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s\n%s", stickyCommentMarker, body), nil
+}
+
+func (r *Reviewer) findStickyComment(number int) (*github.IssueComment, error) {
+	opt := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	comments, err := r.ListIssueComments(number, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Anyone can paste the marker, so only the comments of bump-reviewer itself count
+	for _, c := range comments {
+		if c.GetUser().GetLogin() == r.login && strings.HasPrefix(c.GetBody(), stickyCommentMarker) {
+			return c, nil
+		}
+	}
+
+	return nil, nil
+}
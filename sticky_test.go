@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestReviewer_Review_StickyCommentCreated(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+	reviewer.Sticky = true
+
+	number := 1
+	setPullRequestHandler(mux, number, `{"number":1,"head":{"sha":"abc123"}}`)
+	setPullRequestFilesHandler(mux, number, `[{"filename":"test.rb"}]`)
+	setCreateReviewHandler(mux, number, "COMMENT")
+
+	var created string
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/issues/%d/comments", testGitHubOwner, testGitHubRepo, number), func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprintf(w, `[{"id":1,"body":"Nice PR!"},{"id":3,"body":"%s\nforged","user":{"login":"someone"}}]`, stickyCommentMarker)
+			return
+		}
+
+		testMethod(t, r, http.MethodPost)
+		created = readBody(t, r)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":2}`)
+	})
+
+	if _, ok := reviewer.Review(number).(review); !ok {
+		t.Fatalf("Reviewer.Review did not return a review error")
+	}
+
+	for _, want := range []string{stickyCommentMarker, "review failed", "abc123", "edited unexpected file"} {
+		if !strings.Contains(created, want) {
+			t.Errorf("sticky comment does not contain %q: %s", want, created)
+		}
+	}
+}
+
+func TestReviewer_Review_StickyCommentEdited(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+	reviewer.Sticky = true
+
+	number := 1
+	setPullRequestHandler(mux, number, `{"number":1,"head":{"sha":"def456"}}`)
+	setPullRequestFilesHandler(mux, number, `[{"filename":"test.rb"}]`)
+	setCreateReviewHandler(mux, number, "COMMENT")
+
+	// The sticky comment is on the second page of a long PR
+	u := fmt.Sprintf("/repos/%v/%v/issues/%d/comments", testGitHubOwner, testGitHubRepo, number)
+	mux.HandleFunc(u, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprintf(w, `[{"id":2,"body":"%s\nold","user":{"login":"bump-reviewer-bot"}}]`, stickyCommentMarker)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s?page=2>; rel="next"`, u))
+		fmt.Fprint(w, `[{"id":1,"body":"Nice PR!"}]`)
+	})
+
+	var edited string
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/issues/comments/%d", testGitHubOwner, testGitHubRepo, 2), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPatch)
+		edited = readBody(t, r)
+		fmt.Fprint(w, `{"id":2}`)
+	})
+
+	if _, ok := reviewer.Review(number).(review); !ok {
+		t.Fatalf("Reviewer.Review did not return a review error")
+	}
+
+	for _, want := range []string{stickyCommentMarker, "review failed", "def456", "edited unexpected file"} {
+		if !strings.Contains(edited, want) {
+			t.Errorf("sticky comment does not contain %q: %s", want, edited)
+		}
+	}
+}
+
+func TestReviewer_Review_StickyCommentResolved(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+	reviewer.Sticky = true
+
+	number := 1
+	setPullRequestHandler(mux, number, `{"number":1,"head":{"sha":"def456"}}`)
+	setPullRequestFilesHandler(mux, number, `[{"filename":"lib/bump-reviewer/version.rb"}]`)
+	setCreateReviewHandler(mux, number, "APPROVE")
+	setReleaseHandler(mux, "v1.0.1")
+	setGetContentHandler(mux, "1.0.2")
+	setNewVersionHandler(mux)
+	setChangesHandler(mux)
+	setPullRequestsHandler(mux, "[]")
+	setIssueCommentsHandler(t, mux, number, fmt.Sprintf(`[{"id":2,"body":"%s\nold","user":{"login":"bump-reviewer-bot"}}]`, stickyCommentMarker))
+
+	var edited string
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/issues/comments/%d", testGitHubOwner, testGitHubRepo, 2), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodPatch)
+		edited = readBody(t, r)
+		fmt.Fprint(w, `{"id":2}`)
+	})
+
+	if err := reviewer.Review(number); err != nil {
+		t.Fatalf("Reviewer.Review returned unexpected error: %s", err)
+	}
+
+	for _, want := range []string{stickyCommentMarker, "review passed", "def456"} {
+		if !strings.Contains(edited, want) {
+			t.Errorf("sticky comment does not contain %q: %s", want, edited)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+var hunkHeaderRegex = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// versionSuggestion builds an inline review comment which suggests the correct version line.
+// It returns nil if the version line is not in the patch of the file.
+func versionSuggestion(file *github.CommitFile, m versionMatch, content, version string) *github.DraftReviewComment {
+	position := diffPosition(file.GetPatch(), m.Line)
+	if position == 0 {
+		return nil
+	}
+
+	suggested := m.replaceLine(content, version)
+	body := fmt.Sprintf("bump-reviewer expects the version to be %s.\n\n```suggestion\n%s\n```", version, suggested)
+
+	return &github.DraftReviewComment{
+		Path:     file.Filename,
+		Position: github.Int(position),
+		Body:     github.String(body),
+	}
+}
+
+// diffPosition converts a line number of the new file into a position in the patch,
+// which is what the GitHub API expects for review comments. It returns 0 if the line
+// is not included in the patch.
+func diffPosition(patch string, lineNumber int) int {
+	var newLine int
+	for position, line := range strings.Split(patch, "\n") {
+		if m := hunkHeaderRegex.FindStringSubmatch(line); m != nil {
+			newLine, _ = strconv.Atoi(m[1])
+			continue
+		}
+
+		if strings.HasPrefix(line, "-") || strings.HasPrefix(line, `\`) {
+			continue
+		}
+
+		if newLine == lineNumber {
+			return position
+		}
+		newLine++
+	}
+
+	return 0
+}
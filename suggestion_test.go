@@ -0,0 +1,82 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+const testVersionPatch = `@@ -1,5 +1,5 @@
+ # frozen_string_literal: true
+ 
+ module BumpReviewer
+-  VERSION = '1.0.1'.freeze
++  VERSION = '1.0.3'.freeze
+ end`
+
+func TestDiffPosition(t *testing.T) {
+	multiHunk := `@@ -1,2 +1,2 @@
+-# old
++# new
+ module BumpReviewer
+@@ -10,3 +10,3 @@
+   def foo
+-    1
++    2
+   end`
+
+	cases := []struct {
+		patch      string
+		lineNumber int
+		expected   int
+	}{
+		{patch: testVersionPatch, lineNumber: 4, expected: 5},
+		{patch: testVersionPatch, lineNumber: 1, expected: 1},
+		{patch: testVersionPatch, lineNumber: 5, expected: 6},
+		{patch: testVersionPatch, lineNumber: 9, expected: 0},
+		{patch: multiHunk, lineNumber: 1, expected: 2},
+		{patch: multiHunk, lineNumber: 11, expected: 7},
+		{patch: multiHunk, lineNumber: 5, expected: 0},
+		{patch: "", lineNumber: 1, expected: 0},
+	}
+
+	for i, tc := range cases {
+		if got := diffPosition(tc.patch, tc.lineNumber); got != tc.expected {
+			t.Errorf("#%d diffPosition returned %d, want %d", i, got, tc.expected)
+		}
+	}
+}
+
+func TestVersionSuggestion(t *testing.T) {
+	content := "# frozen_string_literal: true\n\nmodule BumpReviewer\n  VERSION = '1.0.3'.freeze\nend\n"
+	file := &github.CommitFile{Filename: github.String("lib/bump-reviewer/version.rb"), Patch: github.String(testVersionPatch)}
+
+	m, _ := extractVersion(rubyVersionRegex, content)
+	c := versionSuggestion(file, m, content, "1.0.2")
+	if c == nil {
+		t.Fatalf("versionSuggestion returned nil")
+	}
+
+	if got, want := c.GetPath(), "lib/bump-reviewer/version.rb"; got != want {
+		t.Errorf("versionSuggestion returned unexpected path: want: %s, got: %s", want, got)
+	}
+
+	if got, want := c.GetPosition(), 5; got != want {
+		t.Errorf("versionSuggestion returned unexpected position: want: %d, got: %d", want, got)
+	}
+
+	want := "bump-reviewer expects the version to be 1.0.2.\n\n```suggestion\n  VERSION = '1.0.2'.freeze\n```"
+	if got := c.GetBody(); got != want {
+		t.Errorf("versionSuggestion returned unexpected body: want: %s, got: %s", want, got)
+	}
+}
+
+func TestVersionSuggestion_NotInPatch(t *testing.T) {
+	content := "# frozen_string_literal: true\n\n\n\n\n\nmodule BumpReviewer\n  VERSION = '1.0.3'.freeze\nend\n"
+	file := &github.CommitFile{Filename: github.String("lib/bump-reviewer/version.rb"), Patch: github.String(testVersionPatch)}
+
+	m, _ := extractVersion(rubyVersionRegex, content)
+	if c := versionSuggestion(file, m, content, "1.0.2"); c != nil {
+		t.Errorf("versionSuggestion returned %+v, want nil", c)
+	}
+}
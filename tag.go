@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/google/go-github/github"
+)
+
+// tagVersionPlaceholder stands in for the version to find where the version is in tags
+const tagVersionPlaceholder = "\x00"
+
+// tagData is what tag templates are executed with
+type tagData struct {
+	Repo    string
+	Package string
+	Version string
+}
+
+// parseTagTemplate parses a tag template which has the version exactly once
+func parseTagTemplate(text string) (*template.Template, error) {
+	t, err := template.New("tag.template").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("tag.template is invalid: %s", err)
+	}
+
+	var b bytes.Buffer
+	if err := t.Execute(&b, tagData{Repo: "bump-reviewer", Package: "bump-reviewer", Version: tagVersionPlaceholder}); err != nil {
+		return nil, fmt.Errorf("tag.template is invalid: %s", err)
+	}
+
+	if strings.Count(b.String(), tagVersionPlaceholder) != 1 {
+		return nil, fmt.Errorf("tag.template must have {{.Version}} exactly once: %q", text)
+	}
+
+	return t, nil
+}
+
+// formatTag returns the tag of a version
+func (p *Package) formatTag(version string) (string, error) {
+	if p.config.Tag.template == nil {
+		return "v" + version, nil
+	}
+
+	var b bytes.Buffer
+	if err := p.config.Tag.template.Execute(&b, tagData{Repo: p.repo, Package: p.Name, Version: version}); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// parseTag returns the version of a tag. Without a tag template, the prefix "v" or "V" is trimmed.
+func (p *Package) parseTag(tag string) (string, error) {
+	if p.config.Tag.template == nil {
+		return trimTag(tag), nil
+	}
+
+	pattern, err := p.formatTag(tagVersionPlaceholder)
+	if err != nil {
+		return "", err
+	}
+
+	parts := strings.SplitN(pattern, tagVersionPlaceholder, 2)
+	prefix, suffix := parts[0], parts[1]
+	if len(tag) <= len(prefix)+len(suffix) || !strings.HasPrefix(tag, prefix) || !strings.HasSuffix(tag, suffix) {
+		return "", fmt.Errorf("tag %s does not match the tag template %q", tag, p.config.Tag.Template)
+	}
+
+	return tag[len(prefix) : len(tag)-len(suffix)], nil
+}
+
+// versionTags returns the tags a version may have been released as
+func (p *Package) versionTags(version string) ([]string, error) {
+	if p.config.Tag.template == nil {
+		return []string{"v" + version, "V" + version, version}, nil
+	}
+
+	tag, err := p.formatTag(version)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{tag}, nil
+}
+
+// latestRelease returns the highest release of a package whose version matches a given filter.
+// It returns nil if there is no such release. Without a tag template, a filter and maintenance branches, it is the latest release of GitHub.
+// With maintenance branches, the latest release of GitHub may be a backport, so the highest version is looked for instead.
+func latestRelease(client *GitHubClient, pkg *Package, scheme versionScheme, match func(version string) bool) (*github.RepositoryRelease, error) {
+	if pkg.config.Tag.template == nil && match == nil && len(pkg.config.MaintenanceBranches) == 0 {
+		return client.GetLatestRelease()
+	}
+
+	releases, err := client.ListReleases(&github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *github.RepositoryRelease
+	var latestVersion string
+	for _, release := range releases {
+		if release.GetDraft() || release.GetPrerelease() {
+			continue
+		}
+
+		version, err := pkg.parseTag(release.GetTagName())
+		if err != nil {
+			continue
+		}
+
+		v, err := scheme.normalize(version)
+		if err != nil || (match != nil && !match(v)) {
+			continue
+		}
+
+		if latest == nil || scheme.compare(v, latestVersion) > 0 {
+			latest = release
+			latestVersion = v
+		}
+	}
+
+	return latest, nil
+}
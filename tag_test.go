@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testTagPackage(t *testing.T, template string) *Package {
+	c := DefaultConfig()
+	c.Tag.Template = template
+	if err := c.init(); err != nil {
+		t.Fatalf("Config.init returned unexpected error: %s", err)
+	}
+
+	return c.packages("bump-reviewer")[0]
+}
+
+func TestPackage_FormatTag(t *testing.T) {
+	cases := []struct {
+		template string
+		expected string
+	}{
+		{template: "", expected: "v1.2.3"},
+		{template: "{{.Repo}}-v{{.Version}}", expected: "bump-reviewer-v1.2.3"},
+		{template: "bump_reviewer/{{.Version}}", expected: "bump_reviewer/1.2.3"},
+	}
+
+	for i, tc := range cases {
+		got, err := testTagPackage(t, tc.template).formatTag("1.2.3")
+		if err != nil {
+			t.Fatalf("#%d Package.formatTag returned unexpected error: %s", i, err)
+		}
+
+		if got != tc.expected {
+			t.Errorf("#%d Package.formatTag returned %s, want %s", i, got, tc.expected)
+		}
+	}
+}
+
+func TestPackage_ParseTag(t *testing.T) {
+	cases := []struct {
+		template string
+		tag      string
+		expected string
+		err      bool
+	}{
+		{template: "", tag: "v1.2.3", expected: "1.2.3"},
+		{template: "", tag: "V1.2.3", expected: "1.2.3"},
+		{template: "", tag: "1.2.3", expected: "1.2.3"},
+		{template: "{{.Repo}}-v{{.Version}}", tag: "bump-reviewer-v1.2.3", expected: "1.2.3"},
+		{template: "{{.Repo}}-v{{.Version}}", tag: "other-gem-v1.2.3", err: true},
+		{template: "{{.Repo}}-v{{.Version}}", tag: "bump-reviewer-v", err: true},
+		{template: "{{.Repo}}/{{.Version}}", tag: "bump-reviewer/1.2.3", expected: "1.2.3"},
+		{template: "{{.Repo}}/{{.Version}}", tag: "v1.2.3", err: true},
+	}
+
+	for i, tc := range cases {
+		got, err := testTagPackage(t, tc.template).parseTag(tc.tag)
+		if tc.err {
+			if err == nil || !strings.Contains(err.Error(), "does not match the tag template") {
+				t.Errorf("#%d Package.parseTag returned unexpected error: %v", i, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("#%d Package.parseTag returned unexpected error: %s", i, err)
+		}
+
+		if got != tc.expected {
+			t.Errorf("#%d Package.parseTag returned %s, want %s", i, got, tc.expected)
+		}
+	}
+}
+
+func TestPackage_VersionTags(t *testing.T) {
+	got, err := testTagPackage(t, "{{.Repo}}-v{{.Version}}").versionTags("1.2.3")
+	if err != nil {
+		t.Fatalf("Package.versionTags returned unexpected error: %s", err)
+	}
+
+	if want := []string{"bump-reviewer-v1.2.3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Package.versionTags returned %v, want %v", got, want)
+	}
+}
+
+func TestLatestRelease(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	u := fmt.Sprintf("/repos/%s/%s/releases", testGitHubOwner, testGitHubRepo)
+	mux.HandleFunc(u, func(w http.ResponseWriter, r *http.Request) {
+		// The highest release is on the second page
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"tag_name":"bump-reviewer-v1.11.0"}]`)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s?page=2>; rel="next"`, u))
+		fmt.Fprint(w, `[
+			{"tag_name":"other-gem-v3.0.0"},
+			{"tag_name":"bump-reviewer-v1.3.0","draft":true},
+			{"tag_name":"bump-reviewer-v1.2.0"},
+			{"tag_name":"bump-reviewer-vnext"},
+			{"tag_name":"bump-reviewer-v1.10.0"},
+			{"tag_name":"v9.9.9"}
+		]`)
+	})
+
+	release, err := latestRelease(client, testTagPackage(t, "{{.Repo}}-v{{.Version}}"), semverScheme{}, nil)
+	if err != nil {
+		t.Fatalf("latestRelease returned unexpected error: %s", err)
+	}
+
+	if got, want := release.GetTagName(), "bump-reviewer-v1.11.0"; got != want {
+		t.Errorf("latestRelease returned %s, want %s", got, want)
+	}
+
+	release, err = latestRelease(client, testTagPackage(t, "{{.Repo}}/{{.Version}}"), semverScheme{}, nil)
+	if err != nil {
+		t.Fatalf("latestRelease returned unexpected error: %s", err)
+	}
+
+	if release != nil {
+		t.Errorf("latestRelease returned %s, want nil", release.GetTagName())
+	}
+}
+
+func TestReviewer_Review_UnparsableTag(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+
+	number := 1
+	setPullRequestHandler(mux, number, `{"number":1}`)
+	setPullRequestFilesHandler(mux, number, `[{"filename":"lib/bump-reviewer/version.rb"}]`)
+	setCreateReviewHandler(mux, number, "COMMENT")
+	setReleaseHandler(mux, "release-2018")
+	setGetContentHandler(mux, "1.0.2")
+
+	err := reviewer.Review(number)
+	if err == nil || !strings.Contains(err.Error(), "could not parse the version release-2018 of the latest release as a semantic version") {
+		t.Errorf("Reviewer.Review returned unexpected error: %v", err)
+	}
+}
@@ -15,6 +15,8 @@ const (
 	testGitHubOwner = "shuheiktgw"
 	testGitHubRepo  = "bump-reviewer"
 	testGitHubToken = "abcdefg12345"
+
+	testReviewerLogin = "bump-reviewer-bot"
 )
 
 // setup sets up a test HTTP server along with a GitHubClient that is
@@ -69,9 +71,12 @@ func testBody(t *testing.T, r *http.Request, want string) {
 	}
 }
 
+// setupReviewer sets up a Reviewer along with a test HTTP server like setup does.
+// The token is authenticated as testReviewerLogin with "repo" scope.
 func setupReviewer() (reviewer *Reviewer, mux *http.ServeMux, url string, tearDown func()) {
 	client, mux, url, tearDown := setup()
-	return &Reviewer{client}, mux, url, tearDown
+	setAuthenticatedUserHandler(mux, testReviewerLogin, "repo")
+	return &Reviewer{GitHubClient: client}, mux, url, tearDown
 }
 
 func setPullRequestFilesHandler(mux *http.ServeMux, number int, files string) {
@@ -81,17 +86,56 @@ func setPullRequestFilesHandler(mux *http.ServeMux, number int, files string) {
 }
 
 func setCreateReviewHandler(mux *http.ServeMux, number int, state string) {
+	setReviewsHandler(mux, number, state, `[]`)
+}
+
+func setReviewsHandler(mux *http.ServeMux, number int, state, reviews string) {
 	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/pulls/%d/reviews", testGitHubOwner, testGitHubRepo, number), func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, reviews)
+			return
+		}
 		fmt.Fprint(w, fmt.Sprintf(`{"state":"%s"}`, state))
 	})
 }
 
+func setAuthenticatedUserHandler(mux *http.ServeMux, login, scopes string) {
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-OAuth-Scopes", scopes)
+		fmt.Fprintf(w, `{"login":"%s"}`, login)
+	})
+}
+
 func setReleaseHandler(mux *http.ServeMux, tag string) {
 	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/releases/latest", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintf(w, `{"tag_name":"%s"}`, tag)
 	})
 }
 
+func setCompareHandler(mux *http.ServeMux, base, head, comparison string) {
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/compare/%s...%s", testGitHubOwner, testGitHubRepo, base, head), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, comparison)
+	})
+}
+
+// setNewVersionHandler makes every version have neither a tag nor a release
+func setNewVersionHandler(mux *http.ServeMux) {
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/git/refs/tags/", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+	})
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/releases", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+}
+
+// setChangesHandler makes every comparison have changes to release
+func setChangesHandler(mux *http.ServeMux) {
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/compare/", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"ahead","ahead_by":2,"files":[{"filename":"lib/bump-reviewer/version.rb"},{"filename":"lib/bump-reviewer/cli.rb"},{"filename":"gems/gem-a/lib/gem-a.rb"},{"filename":"gems/b/lib/gem_b.rb"}]}`)
+	})
+}
+
 func setGetContentHandler(mux *http.ServeMux, version string) {
 	path := fmt.Sprintf("lib/%s/version.rb", testGitHubRepo)
 	content := fmt.Sprintf(`
@@ -104,3 +148,51 @@ end
 		fmt.Fprintf(w, `{"content":"%s","encoding":"base64"}`, base64.StdEncoding.EncodeToString([]byte(content)))
 	})
 }
+
+// setContentHandler returns a file of a given content
+func setContentHandler(mux *http.ServeMux, path, content string) {
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/contents/%s", testGitHubOwner, testGitHubRepo, path), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"content":"%s","encoding":"base64"}`, base64.StdEncoding.EncodeToString([]byte(content)))
+	})
+}
+
+// setVersionAtRefHandler returns version.rb which has a version given for each ref
+func setVersionAtRefHandler(mux *http.ServeMux, versions map[string]string) {
+	path := fmt.Sprintf("lib/%s/version.rb", testGitHubRepo)
+	mux.HandleFunc(fmt.Sprintf("/repos/%s/%s/contents/%s", testGitHubOwner, testGitHubRepo, path), func(w http.ResponseWriter, r *http.Request) {
+		content := fmt.Sprintf("module BumpReviewer\n  VERSION = \"%s\"\nend\n", versions[r.URL.Query().Get("ref")])
+		fmt.Fprintf(w, `{"content":"%s","encoding":"base64"}`, base64.StdEncoding.EncodeToString([]byte(content)))
+	})
+}
+
+func setPullRequestHandler(mux *http.ServeMux, number int, pr string) {
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/pulls/%d", testGitHubOwner, testGitHubRepo, number), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, pr)
+	})
+}
+
+func setPullRequestsHandler(mux *http.ServeMux, prs string) {
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/pulls", testGitHubOwner, testGitHubRepo), func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, prs)
+	})
+}
+
+func setIssueCommentsHandler(t *testing.T, mux *http.ServeMux, number int, comments string) {
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/issues/%d/comments", testGitHubOwner, testGitHubRepo, number), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, comments)
+	})
+}
+
+func readBody(t *testing.T, r *http.Request) string {
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		t.Errorf("Error reading request body: %v", err)
+	}
+	return string(b)
+}
+
+// testPackage returns the package of a reviewer's repository which has no packages configured
+func testPackage(r *Reviewer) *Package {
+	return configOrDefault(r.Config).packages(r.Repo)[0]
+}
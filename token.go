@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+type tokenError struct {
+	Message string
+}
+
+func (t *tokenError) Error() string {
+	return t.Message
+}
+
+// resolveIdentity resolves the user who owns the token and the token's scopes
+func (r *Reviewer) resolveIdentity() error {
+	user, scopes, err := r.GetAuthenticatedUser()
+	if err != nil {
+		er, ok := err.(*github.ErrorResponse)
+		if ok && er.Response.StatusCode == http.StatusUnauthorized {
+			return &tokenError{Message: fmt.Sprintf("GitHub Personal Access Token is invalid or expired: %s", err)}
+		}
+
+		// Installation tokens of GitHub Apps, including GITHUB_TOKEN of GitHub Actions, cannot read any user
+		if ok && er.Response.StatusCode == http.StatusForbidden {
+			return &tokenError{Message: fmt.Sprintf("GitHub token cannot tell whose token it is: %s. "+
+				"bump-reviewer needs to know who it reviews as, so installation tokens of GitHub Apps and GITHUB_TOKEN of GitHub Actions are not supported. "+
+				"Please use a personal access token or a user access token of a GitHub App", err)}
+		}
+		return err
+	}
+
+	r.login = user.GetLogin()
+	r.scopes = scopes
+
+	return nil
+}
+
+// checkToken checks if the token is able to approve the PR
+func (r *Reviewer) checkToken(pr *github.PullRequest) error {
+	if strings.EqualFold(r.login, pr.GetUser().GetLogin()) {
+		return &tokenError{Message: fmt.Sprintf("GitHub Personal Access Token belongs to @%s, who opened Pull Request #%d. "+
+			"GitHub does not allow to approve your own Pull Request, please use a token of another user", r.login, pr.GetNumber())}
+	}
+
+	// GitHub does not tell the scopes of some tokens, e.g. user access tokens of GitHub Apps
+	if r.scopes == nil {
+		return nil
+	}
+
+	required := []string{"repo", "public_repo"}
+	if pr.GetBase().GetRepo().GetPrivate() {
+		required = []string{"repo"}
+	}
+
+	for _, s := range r.scopes {
+		for _, req := range required {
+			if s == req {
+				return nil
+			}
+		}
+	}
+
+	return &tokenError{Message: fmt.Sprintf("GitHub Personal Access Token of @%s has scopes [%s], but %s scope is required to approve Pull Request #%d",
+		r.login, strings.Join(r.scopes, ", "), strings.Join(required, " or "), pr.GetNumber())}
+}
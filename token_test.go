@@ -0,0 +1,96 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/github"
+)
+
+func TestReviewer_CheckToken(t *testing.T) {
+	cases := []struct {
+		login   string
+		scopes  []string
+		author  string
+		private bool
+		invalid bool
+	}{
+		{login: "bump-reviewer-bot", scopes: []string{"repo"}, author: "shuheiktgw", private: true, invalid: false},
+		{login: "bump-reviewer-bot", scopes: []string{"public_repo"}, author: "shuheiktgw", private: false, invalid: false},
+		{login: "bump-reviewer-bot", scopes: nil, author: "shuheiktgw", private: true, invalid: false},
+		{login: "shuheiktgw", scopes: []string{"repo"}, author: "ShuheiKtgw", private: true, invalid: true},
+		{login: "bump-reviewer-bot", scopes: []string{"public_repo"}, author: "shuheiktgw", private: true, invalid: true},
+		{login: "bump-reviewer-bot", scopes: []string{}, author: "shuheiktgw", private: false, invalid: true},
+	}
+
+	for i, tc := range cases {
+		r := Reviewer{login: tc.login, scopes: tc.scopes}
+		pr := &github.PullRequest{
+			Number: github.Int(1),
+			User:   &github.User{Login: github.String(tc.author)},
+			Base:   &github.PullRequestBranch{Repo: &github.Repository{Private: github.Bool(tc.private)}},
+		}
+
+		err := r.checkToken(pr)
+		if _, ok := err.(*tokenError); ok != tc.invalid {
+			t.Errorf("#%d Reviewer.checkToken returned unexpected error: %v", i, err)
+		}
+	}
+}
+
+func TestReviewer_ResolveIdentity_Unauthorized(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message":"Bad credentials"}`))
+	})
+
+	r := Reviewer{GitHubClient: client}
+	if _, ok := r.resolveIdentity().(*tokenError); !ok {
+		t.Fatalf("Reviewer.resolveIdentity did not return a token error")
+	}
+}
+
+func TestReviewer_ResolveIdentity_InstallationToken(t *testing.T) {
+	client, mux, _, tearDown := setup()
+	defer tearDown()
+
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message":"Resource not accessible by integration"}`))
+	})
+
+	r := Reviewer{GitHubClient: client}
+	err := r.resolveIdentity()
+	if te, ok := err.(*tokenError); !ok || !strings.Contains(te.Message, "installation tokens of GitHub Apps") {
+		t.Fatalf("Reviewer.resolveIdentity returned %v, want a token error", err)
+	}
+}
+
+func TestReviewer_Review_FailWithSelfApproval(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+
+	number := 1
+	setPullRequestHandler(mux, number, `{"number":1,"user":{"login":"`+testReviewerLogin+`"}}`)
+
+	if _, ok := reviewer.Review(number).(*tokenError); !ok {
+		t.Fatalf("Reviewer.Review did not return a token error")
+	}
+}
+
+func TestReviewer_Review_SkipSelfAuthoredWithoutLabel(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+	reviewer.RequiredLabels = []string{"bumpup"}
+
+	number := 1
+	setPullRequestHandler(mux, number, `{"number":1,"user":{"login":"`+testReviewerLogin+`"}}`)
+
+	if s, ok := reviewer.Review(number).(*skipError); !ok || s.Reason != SkipLabel {
+		t.Fatalf("Reviewer.Review did not skip the Pull Request without the label")
+	}
+}
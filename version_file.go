@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// rubyVersionPattern finds the literal of VERSION constant in version.rb
+const rubyVersionPattern = `(?m)^[ \t]*VERSION[ \t]*=[ \t]*['"](?P<version>[^'"\n]*)['"]`
+
+var rubyVersionRegex = regexp.MustCompile(rubyVersionPattern)
+
+// VersionFileConfig configures where the version is. By default, it is VERSION constant of lib/<repo>/version.rb.
+type VersionFileConfig struct {
+	// Path is the path of the file which has the version, such as "VERSION" or "package.json"
+	Path string `json:"path"`
+
+	// Pattern is a regular expression with a named capture group "version", such as `(?m)^(?P<version>\S+)$`.
+	// It is matched against the whole file, and only the version is replaced when bump-reviewer proposes a bump.
+	// Without it, the file is version.rb and VERSION constant is expected in the module of the gem.
+	Pattern string `json:"pattern"`
+
+	pattern *regexp.Regexp
+}
+
+// generic reports whether the version file is other than version.rb of a gem
+func (c VersionFileConfig) generic() bool {
+	return c.Pattern != ""
+}
+
+// parseVersionPattern compiles a pattern which has a named capture group "version"
+func parseVersionPattern(text string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(text)
+	if err != nil {
+		return nil, fmt.Errorf("version_file.pattern is invalid: %s", err)
+	}
+
+	for _, name := range re.SubexpNames() {
+		if name == "version" {
+			return re, nil
+		}
+	}
+
+	return nil, fmt.Errorf("version_file.pattern must have a named capture group \"version\": %q", text)
+}
+
+// versionMatch is a version found in a version file
+type versionMatch struct {
+	Version string
+
+	// Line is the 1-based line number the version is on
+	Line int
+
+	// start and end are the offsets of the version in the file
+	start, end int
+}
+
+// extractVersion finds the version in the content of a version file.
+// It returns false if the pattern does not match.
+func extractVersion(pattern *regexp.Regexp, content string) (versionMatch, bool) {
+	loc := pattern.FindStringSubmatchIndex(content)
+	if loc == nil {
+		return versionMatch{}, false
+	}
+
+	for i, name := range pattern.SubexpNames() {
+		if name != "version" || loc[2*i] < 0 {
+			continue
+		}
+
+		start, end := loc[2*i], loc[2*i+1]
+		return versionMatch{
+			Version: content[start:end],
+			Line:    strings.Count(content[:start], "\n") + 1,
+			start:   start,
+			end:     end,
+		}, true
+	}
+
+	return versionMatch{}, false
+}
+
+// line returns the whole line the version is on, and the offset of the version in it
+func (m versionMatch) line(content string) (string, int) {
+	start := strings.LastIndex(content[:m.start], "\n") + 1
+	end := len(content)
+	if i := strings.Index(content[m.end:], "\n"); i >= 0 {
+		end = m.end + i
+	}
+
+	return content[start:end], m.start - start
+}
+
+// replaceLine returns the line the version is on with another version
+func (m versionMatch) replaceLine(content, version string) string {
+	line, offset := m.line(content)
+	return line[:offset] + version + line[offset+len(m.Version):]
+}
+
+// replace returns the content with another version, keeping everything around the version as it is
+func (m versionMatch) replace(content, version string) string {
+	return content[:m.start] + version + content[m.end:]
+}
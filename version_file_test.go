@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestExtractVersion(t *testing.T) {
+	cases := []struct {
+		pattern string
+		content string
+		version string
+		line    int
+		found   bool
+	}{
+		{pattern: rubyVersionPattern, content: "module BumpReviewer\n  VERSION = '1.0.1'.freeze\nend\n", version: "1.0.1", line: 2, found: true},
+		{pattern: rubyVersionPattern, content: "# VERSION = '0.0.1'\nmodule BumpReviewer\nend\n", found: false},
+		{pattern: `(?m)^(?P<version>\S+)$`, content: "1.2.3\n", version: "1.2.3", line: 1, found: true},
+		{pattern: `"version":\s*"(?P<version>[^"]+)"`, content: "{\n  \"name\": \"app\",\n  \"version\": \"2.0.0\"\n}\n", version: "2.0.0", line: 3, found: true},
+		{pattern: `(?m)^__version__ = "(?P<version>[^"]+)"|^VERSION = (?P<other>\S+)`, content: "VERSION = 1.0.0\n", found: false},
+		{pattern: `(?m)^(?P<version>\S+)$`, content: "", found: false},
+	}
+
+	for i, tc := range cases {
+		m, found := extractVersion(regexp.MustCompile(tc.pattern), tc.content)
+		if found != tc.found {
+			t.Fatalf("#%d extractVersion returned found: %t, want %t", i, found, tc.found)
+		}
+
+		if m.Version != tc.version || m.Line != tc.line {
+			t.Errorf("#%d extractVersion returned %s on line %d, want %s on line %d", i, m.Version, m.Line, tc.version, tc.line)
+		}
+	}
+}
+
+func TestVersionMatch_Replace(t *testing.T) {
+	content := "{\n  \"name\": \"app\",\n  \"version\": \"2.0.0\", \"private\": true\n}\n"
+	m, _ := extractVersion(regexp.MustCompile(`"version":\s*"(?P<version>[^"]+)"`), content)
+
+	if got, want := m.replace(content, "2.0.1"), "{\n  \"name\": \"app\",\n  \"version\": \"2.0.1\", \"private\": true\n}\n"; got != want {
+		t.Errorf("versionMatch.replace returned %q, want %q", got, want)
+	}
+
+	if got, want := m.replaceLine(content, "2.0.1"), `  "version": "2.0.1", "private": true`; got != want {
+		t.Errorf("versionMatch.replaceLine returned %q, want %q", got, want)
+	}
+}
+
+func TestReviewer_CheckVersion_VersionFile(t *testing.T) {
+	conf := DefaultConfig()
+	conf.VersionFile = VersionFileConfig{Path: "VERSION", Pattern: `(?m)^(?P<version>\S+)$`}
+	if err := conf.init(); err != nil {
+		t.Fatalf("Config.init returned unexpected error: %s", err)
+	}
+
+	cases := []struct {
+		content  string
+		expected string
+	}{
+		{content: "1.0.2\n", expected: ""},
+		{content: "\n1.0.2\n", expected: ""},
+		{content: "1.0.3\n", expected: "VERSION skips a version, 1.0.3 is not the next version of 1.0.1."},
+		{content: "\n", expected: "bump-reviewer could not find a version in VERSION with the pattern `(?m)^(?P<version>\\S+)$`."},
+	}
+
+	r := Reviewer{GitHubClient: NewGitHubClient(testGitHubOwner, testGitHubRepo, testGitHubToken), Config: conf}
+	for i, tc := range cases {
+		err := r.checkVersion(testPackage(&r), "1.0.1", tc.content, []string{BumpPatch}, &versionTable{})
+		if tc.expected == "" {
+			if err != nil {
+				t.Errorf("#%d Reviewer.checkVersion returned unexpected error: %s", i, err)
+			}
+			continue
+		}
+
+		re, ok := err.(*reviewError)
+		if !ok || !strings.HasPrefix(re.review(), tc.expected) {
+			t.Errorf("#%d Reviewer.checkVersion returned unexpected error: want: %s, got: %v", i, tc.expected, err)
+		}
+	}
+}
+
+func TestReviewer_Review_VersionFile(t *testing.T) {
+	reviewer, mux, _, tearDown := setupReviewer()
+	defer tearDown()
+	reviewer.Config = DefaultConfig()
+	reviewer.Config.VersionFile = VersionFileConfig{Path: "VERSION", Pattern: `(?m)^(?P<version>\S+)$`}
+	if err := reviewer.Config.init(); err != nil {
+		t.Fatalf("Config.init returned unexpected error: %s", err)
+	}
+
+	number := 1
+	setPullRequestHandler(mux, number, `{"number":1}`)
+	setPullRequestFilesHandler(mux, number, `[{"filename":"VERSION"}]`)
+	setReleaseHandler(mux, "v1.0.1")
+	setNewVersionHandler(mux)
+	setChangesHandler(mux)
+	setPullRequestsHandler(mux, "[]")
+	setContentHandler(mux, "VERSION", "1.0.2\n")
+
+	var body string
+	mux.HandleFunc(fmt.Sprintf("/repos/%v/%v/pulls/%d/reviews", testGitHubOwner, testGitHubRepo, number), func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		body = readBody(t, r)
+		fmt.Fprint(w, `{"state":"APPROVED"}`)
+	})
+
+	if err := reviewer.Review(number); err != nil {
+		t.Fatalf("Reviewer.Review returned unexpected error: %s", err)
+	}
+
+	// The approval describes the version file of the repository
+	if !strings.Contains(body, "PR changes only VERSION") {
+		t.Errorf("Reviewer.Review approved with unexpected body: %s", body)
+	}
+}
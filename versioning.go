@@ -0,0 +1,341 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+)
+
+// Versioning schemes
+const (
+	SchemeSemVer = "semver"
+	SchemeCalVer = "calver"
+)
+
+// versionScheme parses, compares and bumps versions of a versioning scheme
+type versionScheme interface {
+	// name describes the scheme in messages, e.g. "semantic"
+	name() string
+
+	// normalize validates a version and returns its canonical form
+	normalize(version string) (string, error)
+
+	// compare returns -1, 0 or 1 comparing two normalized versions
+	compare(a, b string) int
+
+	// next bumps a given version by a given kind
+	next(version, kind string) (string, error)
+
+	// reject returns why a valid version in a given file can never be accepted, or an empty string
+	reject(file, version string) string
+
+	// expect describes the version bumps of given kinds, e.g. "increments patch version by one"
+	expect(kinds []string) string
+}
+
+// newVersionScheme returns the versioning scheme of a config, now is used by CalVer
+func newVersionScheme(c *Config, now func() time.Time) versionScheme {
+	if c.Versioning.Scheme == SchemeCalVer {
+		return &calverScheme{tokens: c.Versioning.tokens, now: now}
+	}
+
+	return semverScheme{}
+}
+
+// versionScheme returns the version scheme of a package
+func (r *Reviewer) versionScheme(pkg *Package) versionScheme {
+	return newVersionScheme(pkg.config, r.now)
+}
+
+// bumpKindOf returns the kind of version bump from a version to another one.
+// It returns an empty string if it is not a bump by one.
+func bumpKindOf(scheme versionScheme, from, to string) string {
+	normalized, err := scheme.normalize(to)
+	if err != nil {
+		return ""
+	}
+
+	for _, kind := range []string{BumpPatch, BumpMinor, BumpMajor} {
+		if next, err := scheme.next(from, kind); err == nil && next == normalized {
+			return kind
+		}
+	}
+
+	return ""
+}
+
+type semverScheme struct{}
+
+func (semverScheme) name() string {
+	return "semantic"
+}
+
+func (semverScheme) normalize(version string) (string, error) {
+	v, err := semver.New(version)
+	if err != nil {
+		return "", err
+	}
+
+	return v.String(), nil
+}
+
+func (semverScheme) compare(a, b string) int {
+	return semver.MustParse(a).Compare(semver.MustParse(b))
+}
+
+func (semverScheme) next(version, kind string) (string, error) {
+	return nextVersion(version, kind)
+}
+
+func (semverScheme) reject(file, version string) string {
+	return ""
+}
+
+func (semverScheme) expect(kinds []string) string {
+	return fmt.Sprintf("increments %s version by one", strings.Join(kinds, " or "))
+}
+
+// CalVer format tokens
+const (
+	calverFullYear   = "YYYY"
+	calverShortYear  = "YY"
+	calverPaddedYear = "0Y"
+	calverMonth      = "MM"
+	calverPadMonth   = "0M"
+	calverDay        = "DD"
+	calverPadDay     = "0D"
+	calverMinor      = "MINOR"
+	calverMicro      = "MICRO"
+)
+
+// parseCalVerFormat parses a format such as "YYYY.0M.0D" or "YYYY.MINOR.MICRO"
+func parseCalVerFormat(format string) ([]string, error) {
+	tokens := strings.Split(format, ".")
+	seen := map[string]bool{}
+	hasDate := false
+	for _, t := range tokens {
+		switch t {
+		case calverFullYear, calverShortYear, calverPaddedYear, calverMonth, calverPadMonth, calverDay, calverPadDay:
+			hasDate = true
+		case calverMinor, calverMicro:
+		default:
+			return nil, fmt.Errorf("unknown token %q, it must be one of YYYY, YY, 0Y, MM, 0M, DD, 0D, MINOR or MICRO", t)
+		}
+
+		if seen[t] {
+			return nil, fmt.Errorf("token %q appears more than once", t)
+		}
+		seen[t] = true
+	}
+
+	if !hasDate {
+		return nil, fmt.Errorf("%q has no date token", format)
+	}
+
+	return tokens, nil
+}
+
+// calverScheme is calendar versioning, whose date tokens are the period of a release
+// and MINOR and MICRO count releases within the period
+type calverScheme struct {
+	tokens []string
+	now    func() time.Time
+}
+
+func (c *calverScheme) name() string {
+	return "calendar"
+}
+
+func isDateToken(token string) bool {
+	return token != calverMinor && token != calverMicro
+}
+
+func (c *calverScheme) parse(version string) ([]int, error) {
+	parts := strings.Split(version, ".")
+	if len(parts) != len(c.tokens) {
+		return nil, fmt.Errorf("%s does not match the format %s", version, strings.Join(c.tokens, "."))
+	}
+
+	values := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%s does not match the format %s", version, strings.Join(c.tokens, "."))
+		}
+
+		if c.format(c.tokens[i], n) != p {
+			return nil, fmt.Errorf("%s does not match the format %s", version, strings.Join(c.tokens, "."))
+		}
+		values[i] = n
+	}
+
+	return values, nil
+}
+
+func (c *calverScheme) format(token string, n int) string {
+	switch token {
+	case calverPaddedYear, calverPadMonth, calverPadDay:
+		return fmt.Sprintf("%02d", n)
+	}
+
+	return strconv.Itoa(n)
+}
+
+func (c *calverScheme) render(values []int) string {
+	parts := make([]string, len(values))
+	for i, n := range values {
+		parts[i] = c.format(c.tokens[i], n)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// today returns the values of the date tokens of today in UTC, and 0 for the counters.
+// UTC keeps the period the same wherever bump-reviewer runs.
+func (c *calverScheme) today() []int {
+	now := c.now().UTC()
+	values := make([]int, len(c.tokens))
+	for i, t := range c.tokens {
+		switch t {
+		case calverFullYear:
+			values[i] = now.Year()
+		case calverShortYear, calverPaddedYear:
+			values[i] = now.Year() % 100
+		case calverMonth, calverPadMonth:
+			values[i] = int(now.Month())
+		case calverDay, calverPadDay:
+			values[i] = now.Day()
+		}
+	}
+
+	return values
+}
+
+// comparePeriod compares the date tokens of two versions
+func (c *calverScheme) comparePeriod(a, b []int) int {
+	for i, t := range c.tokens {
+		if !isDateToken(t) {
+			continue
+		}
+
+		if d := compareInt(a[i], b[i]); d != 0 {
+			return d
+		}
+	}
+
+	return 0
+}
+
+func (c *calverScheme) normalize(version string) (string, error) {
+	if _, err := c.parse(version); err != nil {
+		return "", err
+	}
+
+	return version, nil
+}
+
+func (c *calverScheme) compare(a, b string) int {
+	va, _ := c.parse(a)
+	vb, _ := c.parse(b)
+	for i := range va {
+		if d := compareInt(va[i], vb[i]); d != 0 {
+			return d
+		}
+	}
+
+	return 0
+}
+
+func (c *calverScheme) next(version, kind string) (string, error) {
+	values, err := c.parse(version)
+	if err != nil {
+		return "", err
+	}
+
+	today := c.today()
+	switch c.comparePeriod(values, today) {
+	case 1:
+		return "", fmt.Errorf("%s is dated after today", version)
+	case -1:
+		// The first release of a new period resets the counters
+		return c.render(today), nil
+	}
+
+	counter := calverMicro
+	switch kind {
+	case BumpPatch:
+	case BumpMinor:
+		counter = calverMinor
+	default:
+		return "", fmt.Errorf("calendar versions cannot have %s version bumps", kind)
+	}
+
+	index := -1
+	for i, t := range c.tokens {
+		if t == counter {
+			index = i
+		}
+	}
+
+	if index == -1 {
+		return "", fmt.Errorf("%s has already been released in the current period, and the format %s has no %s", version, strings.Join(c.tokens, "."), counter)
+	}
+
+	values[index]++
+	for i, t := range c.tokens {
+		if t == calverMicro && counter == calverMinor {
+			values[i] = 0
+		}
+	}
+
+	return c.render(values), nil
+}
+
+func (c *calverScheme) reject(file, version string) string {
+	values, err := c.parse(version)
+	if err != nil {
+		return ""
+	}
+
+	today := c.today()
+	switch c.comparePeriod(values, today) {
+	case 1:
+		return fmt.Sprintf("%s dates %s in the future, but bump-reviewer expects the period of today, %s.", file, version, c.render(today))
+	case -1:
+		return fmt.Sprintf("%s dates %s in the past, but bump-reviewer expects the period of today, %s.", file, version, c.render(today))
+	}
+
+	return ""
+}
+
+func (c *calverScheme) expect(kinds []string) string {
+	var counters []string
+	for _, kind := range kinds {
+		counter := map[string]string{BumpPatch: calverMicro, BumpMinor: calverMinor}[kind]
+		for _, t := range c.tokens {
+			if t == counter {
+				counters = append(counters, counter)
+			}
+		}
+	}
+
+	if len(counters) == 0 {
+		return "dates the calendar version today"
+	}
+
+	return fmt.Sprintf("dates the calendar version today, or increments %s within the period of today", strings.Join(counters, " or "))
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func testCalVerScheme(t *testing.T, format string) *calverScheme {
+	tokens, err := parseCalVerFormat(format)
+	if err != nil {
+		t.Fatalf("parseCalVerFormat returned unexpected error: %s", err)
+	}
+
+	today := time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC)
+	return &calverScheme{tokens: tokens, now: func() time.Time { return today }}
+}
+
+func TestParseCalVerFormat_Invalid(t *testing.T) {
+	cases := []struct {
+		format   string
+		expected string
+	}{
+		{format: "YYYY.MMM", expected: "unknown token"},
+		{format: "MINOR.MICRO", expected: "no date token"},
+		{format: "YYYY.MICRO.MICRO", expected: "more than once"},
+		{format: "", expected: "unknown token"},
+	}
+
+	for i, tc := range cases {
+		if _, err := parseCalVerFormat(tc.format); err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("#%d parseCalVerFormat returned unexpected error: want: %s, got: %v", i, tc.expected, err)
+		}
+	}
+}
+
+func TestCalverScheme_Next(t *testing.T) {
+	cases := []struct {
+		format   string
+		version  string
+		kind     string
+		expected string
+	}{
+		{format: "YYYY.0M.0D", version: "2024.03.01", kind: BumpPatch, expected: "2024.03.05"},
+		{format: "YYYY.MM.DD", version: "2023.12.31", kind: BumpPatch, expected: "2024.3.5"},
+		{format: "YYYY.0M.0D.MICRO", version: "2024.03.05.0", kind: BumpPatch, expected: "2024.03.05.1"},
+		{format: "YYYY.MINOR.MICRO", version: "2024.2.3", kind: BumpPatch, expected: "2024.2.4"},
+		{format: "YYYY.MINOR.MICRO", version: "2024.2.3", kind: BumpMinor, expected: "2024.3.0"},
+		{format: "YYYY.MINOR.MICRO", version: "2023.7.1", kind: BumpPatch, expected: "2024.0.0"},
+		{format: "0Y.0M.MICRO", version: "24.02.4", kind: BumpPatch, expected: "24.03.0"},
+	}
+
+	for i, tc := range cases {
+		got, err := testCalVerScheme(t, tc.format).next(tc.version, tc.kind)
+		if err != nil {
+			t.Fatalf("#%d calverScheme.next returned unexpected error: %s", i, err)
+		}
+
+		if got != tc.expected {
+			t.Errorf("#%d calverScheme.next returned %s, want %s", i, got, tc.expected)
+		}
+	}
+
+	// Today is March 5 in UTC, although it is already March 6 in Tokyo
+	scheme := testCalVerScheme(t, "YYYY.0M.0D")
+	scheme.now = func() time.Time {
+		return time.Date(2024, time.March, 6, 8, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	}
+	if got, err := scheme.next("2024.03.01", BumpPatch); err != nil || got != "2024.03.05" {
+		t.Errorf("calverScheme.next returned %s, %v, want 2024.03.05", got, err)
+	}
+}
+
+func TestCalverScheme_Next_Invalid(t *testing.T) {
+	cases := []struct {
+		format  string
+		version string
+		kind    string
+	}{
+		// Released today already without a counter
+		{format: "YYYY.0M.0D", version: "2024.03.05", kind: BumpPatch},
+		// Released in the future
+		{format: "YYYY.0M.0D", version: "2024.03.06", kind: BumpPatch},
+		{format: "YYYY.0M.0D.MICRO", version: "2024.03.05.0", kind: BumpMinor},
+		{format: "YYYY.MINOR.MICRO", version: "2024.1.0", kind: BumpMajor},
+		{format: "YYYY.0M.0D", version: "2024.3.5", kind: BumpPatch},
+	}
+
+	for i, tc := range cases {
+		if got, err := testCalVerScheme(t, tc.format).next(tc.version, tc.kind); err == nil {
+			t.Errorf("#%d calverScheme.next returned %s, want an error", i, got)
+		}
+	}
+}
+
+func TestCalverScheme_Reject(t *testing.T) {
+	scheme := testCalVerScheme(t, "YYYY.0M.0D.MICRO")
+
+	cases := []struct {
+		version  string
+		expected string
+	}{
+		{version: "2024.03.05.2"},
+		{version: "2024.03.04.0", expected: "VERSION dates 2024.03.04.0 in the past, but bump-reviewer expects the period of today, 2024.03.05.0."},
+		{version: "2024.04.01.0", expected: "VERSION dates 2024.04.01.0 in the future, but bump-reviewer expects the period of today, 2024.03.05.0."},
+	}
+
+	for i, tc := range cases {
+		if got := scheme.reject("VERSION", tc.version); got != tc.expected {
+			t.Errorf("#%d calverScheme.reject returned %q, want %q", i, got, tc.expected)
+		}
+	}
+}
+
+func TestVersionScheme_Expect(t *testing.T) {
+	cases := []struct {
+		scheme   versionScheme
+		kinds    []string
+		expected string
+	}{
+		{scheme: semverScheme{}, kinds: []string{BumpPatch}, expected: "increments patch version by one"},
+		{scheme: semverScheme{}, kinds: []string{BumpMinor, BumpMajor}, expected: "increments minor or major version by one"},
+		{scheme: testCalVerScheme(t, "YYYY.0M.0D"), kinds: []string{BumpPatch}, expected: "dates the calendar version today"},
+		{scheme: testCalVerScheme(t, "YYYY.MINOR.MICRO"), kinds: []string{BumpPatch, BumpMinor}, expected: "dates the calendar version today, or increments MICRO or MINOR within the period of today"},
+	}
+
+	for i, tc := range cases {
+		if got := tc.scheme.expect(tc.kinds); got != tc.expected {
+			t.Errorf("#%d versionScheme.expect returned %q, want %q", i, got, tc.expected)
+		}
+	}
+}
+
+func TestReviewer_CheckVersion_CalVer(t *testing.T) {
+	reviewer := Reviewer{GitHubClient: NewGitHubClient(testGitHubOwner, testGitHubRepo, testGitHubToken), Config: DefaultConfig()}
+	reviewer.Config.Versioning = VersioningConfig{Scheme: SchemeCalVer, Format: "YYYY.0M.0D.MICRO"}
+	if err := reviewer.Config.init(); err != nil {
+		t.Fatalf("Config.init returned unexpected error: %s", err)
+	}
+	reviewer.clock = func() time.Time { return time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC) }
+
+	cases := []struct {
+		version  string
+		rejected bool
+	}{
+		{version: "2024.03.05.0"},
+		{version: "2024.03.05.1", rejected: true},
+		{version: "2024.03.04.0", rejected: true},
+		{version: "2024.03.06.0", rejected: true},
+		{version: "1.0.2", rejected: true},
+	}
+
+	for i, tc := range cases {
+		content := "module BumpReviewer\n  VERSION = \"" + tc.version + "\"\nend\n"
+
+		var table versionTable
+		err := reviewer.checkVersion(testPackage(&reviewer), "2024.02.20.3", content, []string{BumpPatch}, &table)
+		if tc.rejected != (err != nil) {
+			t.Errorf("#%d Reviewer.checkVersion returned unexpected error: %v", i, err)
+		}
+	}
+}